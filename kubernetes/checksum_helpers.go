@@ -0,0 +1,240 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// templateChecksumAnnotation is injected into the pod template of deployments
+// using `template_annotations_from`, so that config changes roll the pods. It
+// holds the checksum of the objects referenced without an `annotation`.
+const templateChecksumAnnotation = "terraform.io/template-checksum"
+
+type configObjectReference struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (r configObjectReference) String() string {
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+func configObjectReferenceFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"kind": {
+			Type:         schema.TypeString,
+			Description:  "Kind of the referenced object. One of ConfigMap or Secret.",
+			Required:     true,
+			ValidateFunc: validateAttributeValueIsIn([]string{"ConfigMap", "Secret"}),
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Description:  "Name of the referenced object.",
+			Required:     true,
+			ValidateFunc: validateName,
+		},
+		"namespace": {
+			Type:        schema.TypeString,
+			Description: "Namespace of the referenced object. Defaults to the namespace of the deployment.",
+			Optional:    true,
+		},
+		"annotation": {
+			Type:         schema.TypeString,
+			Description:  "Annotation of the pod template to set to the checksum of the object, e.g. `checksum/config`. Objects sharing an annotation are hashed together. Defaults to `terraform.io/template-checksum`.",
+			Optional:     true,
+			ValidateFunc: validateAnnotationName,
+		},
+	}
+}
+
+func expandConfigObjectReferences(in []interface{}, defaultNamespace string) []configObjectReference {
+	refs := make([]configObjectReference, 0, len(in))
+	for _, v := range in {
+		m := v.(map[string]interface{})
+		ref := configObjectReference{
+			Kind:      m["kind"].(string),
+			Name:      m["name"].(string),
+			Namespace: defaultNamespace,
+		}
+		if ns, ok := m["namespace"].(string); ok && ns != "" {
+			ref.Namespace = ns
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// expandTemplateAnnotationsFrom groups the references by the annotation their
+// checksum is set to.
+func expandTemplateAnnotationsFrom(in []interface{}, defaultNamespace string) map[string][]configObjectReference {
+	groups := make(map[string][]configObjectReference)
+	for _, v := range in {
+		m := v.(map[string]interface{})
+		annotation := templateChecksumAnnotation
+		if a, ok := m["annotation"].(string); ok && a != "" {
+			annotation = a
+		}
+		groups[annotation] = append(groups[annotation], expandConfigObjectReferences([]interface{}{m}, defaultNamespace)...)
+	}
+	return groups
+}
+
+// parseConfigObjectReference parses references of the form `Kind/name` or
+// `Kind/namespace/name`, as used by `config_checksum_annotations`.
+func parseConfigObjectReference(s, defaultNamespace string) (configObjectReference, error) {
+	parts := strings.Split(s, "/")
+	ref := configObjectReference{Kind: parts[0], Namespace: defaultNamespace}
+	switch len(parts) {
+	case 2:
+		ref.Name = parts[1]
+	case 3:
+		ref.Namespace = parts[1]
+		ref.Name = parts[2]
+	default:
+		return ref, fmt.Errorf("Unexpected format of reference %q, expected Kind/name or Kind/namespace/name", s)
+	}
+	if ref.Kind != "ConfigMap" && ref.Kind != "Secret" {
+		return ref, fmt.Errorf("Unsupported kind of config object in reference %q, expected ConfigMap or Secret", s)
+	}
+	if ref.Namespace == "" || ref.Name == "" {
+		return ref, fmt.Errorf("Reference %q must have a non-empty namespace and name", s)
+	}
+	return ref, nil
+}
+
+func validateConfigObjectReferenceMap(value interface{}, key string) (ws []string, es []error) {
+	for k, v := range value.(map[string]interface{}) {
+		ref, ok := v.(string)
+		if !ok || isUnknownConfigObjectReference(ref) {
+			continue
+		}
+		_, err := parseConfigObjectReference(ref, "default")
+		if err != nil {
+			es = append(es, fmt.Errorf("%s.%s: %s", key, k, err))
+		}
+	}
+	return
+}
+
+// expandConfigChecksumAnnotations adds the objects referenced by
+// `config_checksum_annotations` to the groups of their annotation.
+func expandConfigChecksumAnnotations(in map[string]interface{}, defaultNamespace string, groups map[string][]configObjectReference) error {
+	for annotation, v := range in {
+		s := v.(string)
+		// Kept as the name, so that checksumConfigObjects defers the checksum
+		if isUnknownConfigObjectReference(s) {
+			groups[annotation] = append(groups[annotation], configObjectReference{Name: s})
+			continue
+		}
+		ref, err := parseConfigObjectReference(s, defaultNamespace)
+		if err != nil {
+			return err
+		}
+		groups[annotation] = append(groups[annotation], ref)
+	}
+	return nil
+}
+
+// checksumTemplateAnnotations hashes the objects of each annotation. It
+// returns false if any of them can't be hashed yet.
+func checksumTemplateAnnotations(conn *kubernetes.Clientset, groups map[string][]configObjectReference) (map[string]string, bool, error) {
+	checksums := make(map[string]string, len(groups))
+	for annotation, refs := range groups {
+		checksum, ok, err := checksumConfigObjects(conn, refs)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		checksums[annotation] = checksum
+	}
+	return checksums, true, nil
+}
+
+// isUnknownConfigObjectReference tells whether the reference is interpolated
+// from a resource that isn't created yet.
+func isUnknownConfigObjectReference(s string) bool {
+	return s == "" || strings.Contains(s, config.UnknownVariableValue)
+}
+
+// readConfigObjectData returns the data of the referenced ConfigMap or Secret.
+// The returned error satisfies errors.IsNotFound when the object doesn't exist.
+func readConfigObjectData(conn *kubernetes.Clientset, ref configObjectReference) (map[string][]byte, error) {
+	switch ref.Kind {
+	case "ConfigMap":
+		cfgMap, err := conn.CoreV1().ConfigMaps(ref.Namespace).Get(ref.Name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data := make(map[string][]byte, len(cfgMap.Data))
+		for k, v := range cfgMap.Data {
+			data[k] = []byte(v)
+		}
+		return data, nil
+	case "Secret":
+		secret, err := conn.CoreV1().Secrets(ref.Namespace).Get(ref.Name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return secret.Data, nil
+	}
+	return nil, fmt.Errorf("Unsupported kind of config object: %q", ref.Kind)
+}
+
+// checksumConfigObjects hashes the content of all referenced objects. It
+// returns false if any of them doesn't exist yet, e.g. because it's created
+// in the same apply, in which case the checksum can't be known at plan time.
+func checksumConfigObjects(conn *kubernetes.Clientset, refs []configObjectReference) (string, bool, error) {
+	objects := make([]map[string][]byte, 0, len(refs))
+	for _, ref := range refs {
+		// The name isn't known yet when it's interpolated from a resource to be created
+		if isUnknownConfigObjectReference(ref.Name) {
+			return "", false, nil
+		}
+		data, err := readConfigObjectData(conn, ref)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return "", false, nil
+			}
+			return "", false, fmt.Errorf("Failed to read %s: %s", ref, err)
+		}
+		objects = append(objects, data)
+	}
+	return checksumConfigData(refs, objects), true, nil
+}
+
+func checksumConfigData(refs []configObjectReference, objects []map[string][]byte) string {
+	h := sha256.New()
+	for i, ref := range refs {
+		fmt.Fprintf(h, "%s\n", ref)
+		keys := make([]string, 0, len(objects[i]))
+		for k := range objects[i] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(h, "%s=%x\n", k, objects[i][k])
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// removeAnnotations drops the given annotation keys which are managed by the
+// provider rather than configured by the user.
+func removeAnnotations(m map[string]string, keys ...string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	for _, k := range keys {
+		delete(out, k)
+	}
+	return out
+}
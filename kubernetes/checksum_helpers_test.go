@@ -0,0 +1,216 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+)
+
+func TestExpandConfigObjectReferences(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{"kind": "ConfigMap", "name": "app-config", "namespace": ""},
+		map[string]interface{}{"kind": "Secret", "name": "app-secret", "namespace": "shared"},
+	}
+	expected := []configObjectReference{
+		{Kind: "ConfigMap", Namespace: "web", Name: "app-config"},
+		{Kind: "Secret", Namespace: "shared", Name: "app-secret"},
+	}
+
+	out := expandConfigObjectReferences(in, "web")
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected references.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestExpandTemplateAnnotationsFrom(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{"kind": "ConfigMap", "name": "app-config", "annotation": ""},
+		map[string]interface{}{"kind": "Secret", "name": "app-secret", "annotation": "checksum/secret"},
+		map[string]interface{}{"kind": "ConfigMap", "name": "app-env", "namespace": "shared"},
+	}
+	expected := map[string][]configObjectReference{
+		templateChecksumAnnotation: {
+			{Kind: "ConfigMap", Namespace: "web", Name: "app-config"},
+			{Kind: "ConfigMap", Namespace: "shared", Name: "app-env"},
+		},
+		"checksum/secret": {
+			{Kind: "Secret", Namespace: "web", Name: "app-secret"},
+		},
+	}
+
+	out := expandTemplateAnnotationsFrom(in, "web")
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected references.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestParseConfigObjectReference(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected configObjectReference
+		Err      bool
+	}{
+		{"ConfigMap/app-config", configObjectReference{Kind: "ConfigMap", Namespace: "web", Name: "app-config"}, false},
+		{"Secret/shared/app-secret", configObjectReference{Kind: "Secret", Namespace: "shared", Name: "app-secret"}, false},
+		{"app-config", configObjectReference{}, true},
+		{"Pod/app", configObjectReference{}, true},
+		{"ConfigMap/", configObjectReference{}, true},
+		{"Secret/a/b/c", configObjectReference{}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			out, err := parseConfigObjectReference(tc.Input, "web")
+			if tc.Err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %#v", out)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != tc.Expected {
+				t.Fatalf("Unexpected reference.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestValidateConfigObjectReferenceMap(t *testing.T) {
+	_, es := validateConfigObjectReferenceMap(map[string]interface{}{
+		"checksum/config": "ConfigMap/app-config",
+		"checksum/secret": "Secret/" + config.UnknownVariableValue,
+	}, "config_checksum_annotations")
+	if len(es) > 0 {
+		t.Fatalf("Unexpected errors: %v", es)
+	}
+
+	_, es = validateConfigObjectReferenceMap(map[string]interface{}{
+		"checksum/config": "configmap/app-config",
+	}, "config_checksum_annotations")
+	if len(es) != 1 {
+		t.Fatalf("Expected 1 error, got: %v", es)
+	}
+}
+
+func TestExpandConfigChecksumAnnotations(t *testing.T) {
+	groups := expandTemplateAnnotationsFrom([]interface{}{
+		map[string]interface{}{"kind": "ConfigMap", "name": "app-env", "annotation": "checksum/config"},
+	}, "web")
+	err := expandConfigChecksumAnnotations(map[string]interface{}{
+		"checksum/config": "ConfigMap/app-config",
+		"checksum/secret": "Secret/shared/app-secret",
+	}, "web", groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]configObjectReference{
+		"checksum/config": {
+			{Kind: "ConfigMap", Namespace: "web", Name: "app-env"},
+			{Kind: "ConfigMap", Namespace: "web", Name: "app-config"},
+		},
+		"checksum/secret": {
+			{Kind: "Secret", Namespace: "shared", Name: "app-secret"},
+		},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf("Unexpected references.\nExpected: %#v\nGiven:    %#v", expected, groups)
+	}
+}
+
+func TestExpandConfigChecksumAnnotations_deferred(t *testing.T) {
+	groups := make(map[string][]configObjectReference)
+	err := expandConfigChecksumAnnotations(map[string]interface{}{
+		"checksum/config": "ConfigMap/" + config.UnknownVariableValue,
+	}, "default", groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok, err := checksumTemplateAnnotations(nil, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("Expected the checksums to be deferred while the reference is unknown")
+	}
+}
+
+func TestChecksumTemplateAnnotations_deferred(t *testing.T) {
+	_, ok, err := checksumTemplateAnnotations(nil, map[string][]configObjectReference{
+		"checksum/config": {{Kind: "ConfigMap", Namespace: "default", Name: config.UnknownVariableValue}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("Expected the checksums to be deferred while the reference is unknown")
+	}
+}
+
+func TestChecksumConfigData(t *testing.T) {
+	refs := []configObjectReference{
+		{Kind: "ConfigMap", Namespace: "default", Name: "app-config"},
+	}
+	checksum := checksumConfigData(refs, []map[string][]byte{
+		{"a": []byte("1"), "b": []byte("2")},
+	})
+
+	cases := []struct {
+		Name    string
+		Refs    []configObjectReference
+		Objects []map[string][]byte
+		Same    bool
+	}{
+		{
+			"same content",
+			refs,
+			[]map[string][]byte{{"b": []byte("2"), "a": []byte("1")}},
+			true,
+		},
+		{
+			"changed value",
+			refs,
+			[]map[string][]byte{{"a": []byte("1"), "b": []byte("3")}},
+			false,
+		},
+		{
+			"value moved to another key",
+			refs,
+			[]map[string][]byte{{"a": []byte("12"), "b": []byte("")}},
+			false,
+		},
+		{
+			"other object with the same content",
+			[]configObjectReference{{Kind: "Secret", Namespace: "default", Name: "app-config"}},
+			[]map[string][]byte{{"a": []byte("1"), "b": []byte("2")}},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := checksumConfigData(tc.Refs, tc.Objects)
+			if (out == checksum) != tc.Same {
+				t.Fatalf("Expected checksum equality to be %t, got %q and %q", tc.Same, checksum, out)
+			}
+		})
+	}
+}
+
+func TestRemoveAnnotations(t *testing.T) {
+	in := map[string]string{
+		"description":              "web",
+		templateChecksumAnnotation: "abc",
+	}
+
+	out := removeAnnotations(in, templateChecksumAnnotation)
+	expected := map[string]string{"description": "web"}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected annotations.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+	if _, ok := in[templateChecksumAnnotation]; !ok {
+		t.Fatal("Expected the input annotations to be left untouched")
+	}
+}
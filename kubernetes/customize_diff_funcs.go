@@ -0,0 +1,251 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+// podSpecCustomizeDiff returns a CustomizeDiffFunc running the plan-time checks
+// of the pod spec found at prefix, e.g. "spec.0.template.0.spec.0.".
+func podSpecCustomizeDiff(prefix string) schema.CustomizeDiffFunc {
+	checks := []schema.CustomizeDiffFunc{
+		validatePodSpecVolumeSources(prefix),
+		validatePodSpecTolerations(prefix),
+		validatePodSpecEnvVarReferences(prefix),
+		validatePodSpecTerminationGracePeriod(prefix),
+		validatePodSpecReadOnlyRootFilesystem(prefix),
+	}
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, check := range checks {
+			if err := check(d, meta); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// validatePodSpecVolumeSources returns a CustomizeDiffFunc checking that every
+// volume of the pod spec found at prefix sets exactly one volume source.
+func validatePodSpecVolumeSources(prefix string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		volumes, _ := d.Get(prefix + "volume").([]interface{})
+		for i, v := range volumes {
+			volume, _ := v.(map[string]interface{})
+			if err := validateVolumeSource(volume); err != nil {
+				return fmt.Errorf("%svolume.%d: %s", prefix, i, err)
+			}
+		}
+		return nil
+	}
+}
+
+// validateVolumeSource checks that exactly one of the volume sources of
+// volumeSchema is set on the volume.
+func validateVolumeSource(volume map[string]interface{}) error {
+	var sources, found []string
+	for k := range volumeSchema().Schema {
+		if k == "name" {
+			continue
+		}
+		sources = append(sources, k)
+		if l, ok := volume[k].([]interface{}); ok && len(l) > 0 {
+			found = append(found, k)
+		}
+	}
+	sort.Strings(sources)
+	sort.Strings(found)
+
+	name, _ := volume["name"].(string)
+	switch len(found) {
+	case 1:
+		return nil
+	case 0:
+		return fmt.Errorf("volume %q has no source, one of %s must be set (use `empty_dir {}` for scratch space)",
+			name, strings.Join(sources, ", "))
+	default:
+		return fmt.Errorf("volume %q must have exactly one source, found %s", name, strings.Join(found, ", "))
+	}
+}
+
+// validatePodSpecTolerations returns a CustomizeDiffFunc checking that the
+// operator of every toleration of the pod spec found at prefix matches its key
+// and value, which the API would only reject when applying.
+func validatePodSpecTolerations(prefix string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		tolerations, _ := d.Get(prefix + "toleration").([]interface{})
+		for i, t := range tolerations {
+			toleration, _ := t.(map[string]interface{})
+			if err := validateToleration(toleration); err != nil {
+				return fmt.Errorf("%stoleration.%d: %s", prefix, i, err)
+			}
+		}
+		return nil
+	}
+}
+
+// validateToleration checks that a toleration with the Exists operator has no
+// value, and that only such a toleration leaves the key empty.
+func validateToleration(toleration map[string]interface{}) error {
+	key, _ := toleration["key"].(string)
+	operator, _ := toleration["operator"].(string)
+	value, _ := toleration["value"].(string)
+	if strings.Contains(key, config.UnknownVariableValue) || strings.Contains(value, config.UnknownVariableValue) {
+		return nil
+	}
+	if operator == string(api.TolerationOpExists) && value != "" {
+		return fmt.Errorf("value must be empty when operator is %q, given %q", operator, value)
+	}
+	if key == "" && operator != string(api.TolerationOpExists) {
+		return fmt.Errorf("operator must be %q when key is empty", api.TolerationOpExists)
+	}
+	return nil
+}
+
+// validatePodSpecEnvVarReferences returns a CustomizeDiffFunc checking the
+// containers of the pod spec found at prefix (e.g. "spec.0.template.0.spec.0.")
+// for `$(VAR)` references that Kubernetes wouldn't be able to expand.
+// The check only runs when enabled with the provider's `validate_env_var_references`.
+func validatePodSpecEnvVarReferences(prefix string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !meta.(*kubeProvider).validateEnvVarReferences {
+			return nil
+		}
+
+		var errs []string
+		for _, key := range []string{"init_container", "container"} {
+			containers, _ := d.Get(prefix + key).([]interface{})
+			for _, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				errs = append(errs, undefinedEnvVarReferences(container)...)
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("Undefined environment variable references, Kubernetes will leave them unexpanded "+
+				"(escape them as $$(VAR) if this is intended):\n\t%s", strings.Join(errs, "\n\t"))
+		}
+		return nil
+	}
+}
+
+// validatePodSpecTerminationGracePeriod returns a CustomizeDiffFunc checking
+// that the containers of the pod spec found at prefix can stop gracefully
+// within termination_grace_period_seconds. Terraform can't show warnings from
+// checks spanning several attributes, so it fails the plan and only runs when
+// enabled with the provider's `validate_termination_grace_period`.
+func validatePodSpecTerminationGracePeriod(prefix string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !meta.(*kubeProvider).validateTerminationGracePeriod {
+			return nil
+		}
+
+		gracePeriod, _ := d.Get(prefix + "termination_grace_period_seconds").(int)
+		var errs []string
+		containers, _ := d.Get(prefix + "container").([]interface{})
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if msg, overrun := terminationGracePeriodOverrun(container, gracePeriod); overrun {
+				errs = append(errs, msg)
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("Containers may be killed before terminating gracefully, "+
+				"increase termination_grace_period_seconds:\n\t%s", strings.Join(errs, "\n\t"))
+		}
+		return nil
+	}
+}
+
+// validatePodSpecReadOnlyRootFilesystem returns a CustomizeDiffFunc checking
+// that the containers of the pod spec found at prefix which have a read-only
+// root filesystem can still write to readOnlyRootFilesystemWritablePaths. This
+// is a heuristic, so like validatePodSpecTerminationGracePeriod it only runs
+// when enabled with the provider's `validate_read_only_root_filesystem`.
+func validatePodSpecReadOnlyRootFilesystem(prefix string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !meta.(*kubeProvider).validateReadOnlyRootFilesystem {
+			return nil
+		}
+
+		volumes, _ := d.Get(prefix + "volume").([]interface{})
+		var errs []string
+		for _, key := range []string{"init_container", "container"} {
+			containers, _ := d.Get(prefix + key).([]interface{})
+			for _, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if missing := missingWritablePaths(container, volumes); len(missing) > 0 {
+					name, _ := container["name"].(string)
+					errs = append(errs, fmt.Sprintf("container %q: %s", name, strings.Join(missing, ", ")))
+				}
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("Containers with a read-only root filesystem can't write to these paths, "+
+				"mount a writable volume such as an empty_dir there:\n\t%s", strings.Join(errs, "\n\t"))
+		}
+		return nil
+	}
+}
+
+// validateStatefulSetService checks that the service governing a stateful
+// set, `spec.0.service_name`, is headless, without which the pods get no DNS
+// names. The check needs an API request on every plan, so it only runs when
+// enabled with the provider's `validate_stateful_set_service`. It is skipped
+// while the name or namespace are unknown, e.g. when they reference a service
+// created in the same run. A service that doesn't exist yet, or can't be read,
+// only logs a warning: it may be created outside of this configuration.
+func validateStatefulSetService(d *schema.ResourceDiff, meta interface{}) error {
+	p := meta.(*kubeProvider)
+	if !p.validateStatefulSetService {
+		return nil
+	}
+
+	name := d.Get("spec.0.service_name").(string)
+	namespace := d.Get("metadata.0.namespace").(string)
+	if name == "" || namespace == "" {
+		return nil
+	}
+
+	svc, err := p.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsForbidden(err) {
+			log.Printf("[DEBUG] Not allowed to read service %q, skipping its validation: %s", name, err)
+			return nil
+		}
+		if errors.IsNotFound(err) {
+			log.Printf("[WARN] Service %q governing the stateful set does not exist in namespace %q, "+
+				"the pods get no DNS names until a headless service (cluster_ip = \"None\") is created", name, namespace)
+			return nil
+		}
+		log.Printf("[WARN] Failed to check service %q, skipping its validation: %s", name, err)
+		return nil
+	}
+	return statefulSetServiceError(svc)
+}
+
+// statefulSetServiceError returns an error when the service can't govern a
+// stateful set, i.e. when it isn't headless.
+func statefulSetServiceError(svc *api.Service) error {
+	if svc.Spec.ClusterIP == api.ClusterIPNone {
+		return nil
+	}
+	return fmt.Errorf("Service %q governing the stateful set is not headless (cluster_ip is %q), "+
+		"set cluster_ip = \"None\" for the pods to get DNS names", svc.Name, svc.Spec.ClusterIP)
+}
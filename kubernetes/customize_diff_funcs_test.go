@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestValidateVolumeSource(t *testing.T) {
+	testCases := []struct {
+		Volume        map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			map[string]interface{}{
+				"name":      "data",
+				"empty_dir": []interface{}{map[string]interface{}{"medium": ""}},
+			},
+			"",
+		},
+		{
+			map[string]interface{}{
+				"name":      "data",
+				"empty_dir": []interface{}{map[string]interface{}{"medium": ""}},
+				"host_path": []interface{}{map[string]interface{}{"path": "/data"}},
+				"secret":    []interface{}{},
+			},
+			`volume "data" must have exactly one source, found empty_dir, host_path`,
+		},
+		{
+			map[string]interface{}{
+				"name":       "data",
+				"config_map": []interface{}{},
+			},
+			`volume "data" has no source, one of `,
+		},
+	}
+	for _, tc := range testCases {
+		err := validateVolumeSource(tc.Volume)
+		if tc.ExpectedError == "" {
+			if err != nil {
+				t.Fatalf("Expected volume %#v to be valid, given: %s", tc.Volume, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("Expected volume %#v to be invalid", tc.Volume)
+		}
+		if !strings.Contains(err.Error(), tc.ExpectedError) {
+			t.Fatalf("Unexpected error.\nExpected to contain: %s\nGiven: %s", tc.ExpectedError, err)
+		}
+	}
+}
+
+func TestValidateVolumeSource_listsAllSources(t *testing.T) {
+	err := validateVolumeSource(map[string]interface{}{"name": "data"})
+	if err == nil {
+		t.Fatal("Expected a volume without source to be invalid")
+	}
+	for k := range volumeSchema().Schema {
+		if k == "name" {
+			continue
+		}
+		if !strings.Contains(err.Error(), k) {
+			t.Fatalf("Expected error to list the %q source, given: %s", k, err)
+		}
+	}
+}
+
+func TestValidateToleration(t *testing.T) {
+	testCases := []struct {
+		Toleration    map[string]interface{}
+		ExpectedError string
+	}{
+		{map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "gpu"}, ""},
+		{map[string]interface{}{"key": "dedicated", "operator": "Exists"}, ""},
+		{map[string]interface{}{"operator": "Exists"}, ""},
+		{map[string]interface{}{"key": "dedicated", "operator": "Exists", "value": "gpu"}, `value must be empty when operator is "Exists"`},
+		{map[string]interface{}{"operator": "Equal", "value": "gpu"}, `operator must be "Exists" when key is empty`},
+	}
+	for _, tc := range testCases {
+		err := validateToleration(tc.Toleration)
+		if tc.ExpectedError == "" {
+			if err != nil {
+				t.Fatalf("Expected toleration %#v to be valid, given: %s", tc.Toleration, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+			t.Fatalf("Expected error containing %q for toleration %#v, given: %v", tc.ExpectedError, tc.Toleration, err)
+		}
+	}
+}
+
+func TestValidateStatefulSetService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/services/headless":
+			w.Write([]byte(`{"metadata":{"name":"headless"},"spec":{"clusterIP":"None"}}`))
+		case "/api/v1/namespaces/default/services/clustered":
+			w.Write([]byte(`{"metadata":{"name":"clustered"},"spec":{"clusterIP":"10.0.0.1"}}`))
+		case "/api/v1/namespaces/default/services/unavailable":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"InternalError","code":500}`))
+		case "/api/v1/namespaces/default/services/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		}
+	}))
+	defer server.Close()
+
+	k, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		ServiceName   string
+		Enabled       bool
+		ExpectedError string
+	}{
+		{"headless", true, ""},
+		{"clustered", true, "is not headless"},
+		{"missing", true, ""},
+		{"forbidden", true, ""},
+		{"unavailable", true, ""},
+		{"missing", false, ""},
+	}
+	for _, tc := range testCases {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "web"}},
+			"spec": []interface{}{map[string]interface{}{
+				"selector":     []interface{}{map[string]interface{}{"match_labels": map[string]interface{}{"app": "web"}}},
+				"service_name": tc.ServiceName,
+				"template": []interface{}{map[string]interface{}{
+					"metadata": []interface{}{map[string]interface{}{"labels": map[string]interface{}{"app": "web"}}},
+					"spec": []interface{}{map[string]interface{}{
+						"container": []interface{}{map[string]interface{}{"name": "web", "image": "nginx"}},
+					}},
+				}},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta := &kubeProvider{Clientset: k, validateStatefulSetService: tc.Enabled}
+		_, err = resourceKubernetesStatefulSet().Diff(nil, terraform.NewResourceConfig(raw), meta)
+		if tc.ExpectedError == "" {
+			if err != nil {
+				t.Fatalf("Unexpected error for service %q: %s", tc.ServiceName, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+			t.Fatalf("Expected error containing %q for service %q, given: %v", tc.ExpectedError, tc.ServiceName, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesAllNamespaces() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesAllNamespacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"label_selector": {
+				Type:         schema.TypeString,
+				Description:  "A label query to filter the namespaces by, e.g. `team=payments,env!=dev`. Lists all namespaces when unset. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+				Optional:     true,
+				ValidateFunc: validateLabelSelector,
+			},
+			"namespaces": {
+				Type:        schema.TypeList,
+				Description: "List of the names of the matching namespaces, sorted alphabetically.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesAllNamespacesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	selector := d.Get("label_selector").(string)
+	log.Printf("[INFO] Listing namespaces matching %q", selector)
+	nsList, err := conn.CoreV1().Namespaces().List(meta_v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("Failed to list namespaces: %s", err)
+	}
+	log.Printf("[INFO] Received %d namespaces", len(nsList.Items))
+
+	names := make([]string, 0, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+
+	err = d.Set("namespaces", names)
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%d", hashcode.String(selector+"/"+strings.Join(names, ","))))
+
+	return nil
+}
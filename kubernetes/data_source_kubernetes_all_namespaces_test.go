@@ -0,0 +1,59 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceAllNamespaces_labelSelector(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceAllNamespacesConfig_labelSelector(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_all_namespaces.test", "namespaces.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_all_namespaces.test", "namespaces.0", name),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceAllNamespaces_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "kubernetes_all_namespaces" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kubernetes_all_namespaces.test", "namespaces.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceAllNamespacesConfig_labelSelector(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		labels {
+			tenant = "%s"
+		}
+		name = "%s"
+	}
+}
+
+data "kubernetes_all_namespaces" "test" {
+	label_selector = "tenant=${kubernetes_namespace.test.metadata.0.labels.tenant}"
+}
+`, name, name)
+}
@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesDeployment() *schema.Resource {
+	deployment := resourceKubernetesDeployment().Schema
+
+	return &schema.Resource{
+		Read: dataSourceKubernetesDeploymentRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("deployment", false),
+			"spec":     computedSchema(deployment["spec"]),
+			"status":   computedSchema(deployment["status"]),
+		},
+	}
+}
+
+// computedSchema returns a copy of the schema of a resource attribute where
+// the attribute and all its nested attributes are only computed, so that data
+// sources can expose what the resource flattens without redefining it.
+// Removed attributes are left out.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	out := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Computed:    true,
+		Set:         s.Set,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		out.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		fields := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			if v.Removed != "" {
+				continue
+			}
+			fields[k] = computedSchema(v)
+		}
+		out.Elem = &schema.Resource{Schema: fields}
+	}
+	return out
+}
+
+func dataSourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	om := meta_v1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+
+	log.Printf("[INFO] Reading deployment %s", om.Name)
+	deployment, err := conn.ExtensionsV1beta1().Deployments(om.Namespace).Get(om.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Deployment %q not found in namespace %q", om.Name, om.Namespace)
+		}
+		return fmt.Errorf("Failed to read deployment %q: %s", om.Name, err)
+	}
+	log.Printf("[INFO] Received deployment: %#v", deployment)
+	d.SetId(buildId(deployment.ObjectMeta))
+
+	err = d.Set("metadata", flattenMetadata(deployment.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+
+	spec, err := flattenDeploymentSpec(deployment.Spec, d)
+	if err != nil {
+		return err
+	}
+	err = d.Set("spec", spec)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("status", flattenDeploymentStatus(deployment.Status))
+}
@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceDeployment_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceDeploymentConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "metadata.0.namespace", "default"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_deployment.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_deployment.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_deployment.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "spec.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "status.0.updated_replicas", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceDeployment_notFound(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDataSourceDeploymentConfig_notFound(name),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("Deployment %q not found in namespace \"default\"", name)),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceDeploymentConfig_basic(name string) string {
+	return testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.7.8", 2, "") + `
+data "kubernetes_deployment" "test" {
+	metadata {
+		name = "${kubernetes_deployment.test.metadata.0.name}"
+	}
+}
+`
+}
+
+func testAccKubernetesDataSourceDeploymentConfig_notFound(name string) string {
+	return fmt.Sprintf(`
+data "kubernetes_deployment" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+`, name)
+}
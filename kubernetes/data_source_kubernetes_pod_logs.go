@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func dataSourceKubernetesPodLogs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesPodLogsRead,
+
+		Schema: map[string]*schema.Schema{
+			"pod": {
+				Type:         schema.TypeString,
+				Description:  "Name of the pod to read the logs of.",
+				Required:     true,
+				ValidateFunc: validateName,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the pod.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"container": {
+				Type:        schema.TypeString,
+				Description: "Name of the container to read the logs of. Can be omitted if the pod has a single container.",
+				Optional:    true,
+			},
+			"tail_lines": {
+				Type:         schema.TypeInt,
+				Description:  "Number of lines from the end of the logs to return. Returns all logs when unset.",
+				Optional:     true,
+				ValidateFunc: validatePositiveInteger,
+			},
+			"previous": {
+				Type:        schema.TypeBool,
+				Description: "Whether to return the logs of the previous, terminated container instead, e.g. to see why it crashed.",
+				Optional:    true,
+				Default:     false,
+			},
+			"logs": {
+				Type:        schema.TypeString,
+				Description: "The log lines of the container.",
+				Computed:    true,
+			},
+			"lines": {
+				Type:        schema.TypeList,
+				Description: "The log lines of the container, as a list.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesPodLogsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace := d.Get("namespace").(string)
+	name := d.Get("pod").(string)
+	opts := &api.PodLogOptions{
+		Container: d.Get("container").(string),
+		Previous:  d.Get("previous").(bool),
+	}
+	if v, ok := d.GetOk("tail_lines"); ok {
+		opts.TailLines = ptrToInt64(int64(v.(int)))
+	}
+
+	log.Printf("[INFO] Reading logs of pod %s/%s: %#v", namespace, name, opts)
+	out, err := conn.CoreV1().Pods(namespace).GetLogs(name, opts).DoRaw()
+	if err != nil {
+		return fmt.Errorf("Failed to read logs of pod %s/%s: %s", namespace, name, err)
+	}
+	logs := string(out)
+	log.Printf("[INFO] Received %d bytes of logs", len(logs))
+
+	err = d.Set("logs", logs)
+	if err != nil {
+		return err
+	}
+	err = d.Set("lines", splitLogLines(logs))
+	if err != nil {
+		return err
+	}
+	// Data sources are read on every refresh, so the logs are always current
+	d.SetId(fmt.Sprintf("%s/%s/%s", namespace, name, opts.Container))
+
+	return nil
+}
+
+func splitLogLines(logs string) []string {
+	logs = strings.TrimSuffix(logs, "\n")
+	if logs == "" {
+		return []string{}
+	}
+	return strings.Split(logs, "\n")
+}
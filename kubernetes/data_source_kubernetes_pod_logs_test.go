@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourcePodLogs_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourcePodLogsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_pod_logs.test", "lines.#", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_pod_logs.test", "lines.0", "two"),
+					resource.TestCheckResourceAttr("data.kubernetes_pod_logs.test", "lines.1", "three"),
+					resource.TestCheckResourceAttr("data.kubernetes_pod_logs.test", "logs", "two\nthree\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitLogLines(t *testing.T) {
+	cases := []struct {
+		Logs     string
+		Expected []string
+	}{
+		{"", []string{}},
+		{"one\n", []string{"one"}},
+		{"one\ntwo\n", []string{"one", "two"}},
+		{"one\n\ntwo", []string{"one", "", "two"}},
+	}
+
+	for _, tc := range cases {
+		out := splitLogLines(tc.Logs)
+		if !reflect.DeepEqual(out, tc.Expected) {
+			t.Fatalf("Unexpected lines for %q.\nExpected: %#v\nGiven:    %#v", tc.Logs, tc.Expected, out)
+		}
+	}
+}
+
+func testAccKubernetesDataSourcePodLogsConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		restart_policy = "Never"
+		container {
+			image   = "busybox"
+			name    = "printer"
+			command = ["sh", "-c", "echo one; echo two; echo three; sleep 3600"]
+		}
+	}
+}
+
+data "kubernetes_pod_logs" "test" {
+	pod        = "${kubernetes_pod.test.metadata.0.name}"
+	container  = "printer"
+	tail_lines = 2
+}
+`, name)
+}
@@ -0,0 +1,232 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// resourceLister lists the metadata of the objects managed by a resource type
+// of this provider. The namespace is ignored for cluster-scoped types.
+type resourceLister func(conn *kubernetes.Clientset, namespace string, opts meta_v1.ListOptions) ([]meta_v1.ObjectMeta, error)
+
+// listFunc makes the List call of a resource type.
+type listFunc func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error)
+
+// importableResourceListers maps the resource types which can be imported to
+// the lister of their objects.
+var importableResourceListers = map[string]resourceLister{
+	"kubernetes_cluster_role": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.RbacV1beta1().ClusterRoles().List(opts)
+	}),
+	"kubernetes_config_map": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().ConfigMaps(ns).List(opts)
+	}),
+	"kubernetes_daemonset": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.ExtensionsV1beta1().DaemonSets(ns).List(opts)
+	}),
+	"kubernetes_deployment": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.ExtensionsV1beta1().Deployments(ns).List(opts)
+	}),
+	"kubernetes_endpoints": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().Endpoints(ns).List(opts)
+	}),
+	"kubernetes_horizontal_pod_autoscaler": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.AutoscalingV1().HorizontalPodAutoscalers(ns).List(opts)
+	}),
+	"kubernetes_ingress": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.ExtensionsV1beta1().Ingresses(ns).List(opts)
+	}),
+	"kubernetes_job": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.BatchV1().Jobs(ns).List(opts)
+	}),
+	"kubernetes_limit_range": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().LimitRanges(ns).List(opts)
+	}),
+	"kubernetes_namespace": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().Namespaces().List(opts)
+	}),
+	"kubernetes_persistent_volume": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().PersistentVolumes().List(opts)
+	}),
+	"kubernetes_persistent_volume_claim": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().PersistentVolumeClaims(ns).List(opts)
+	}),
+	"kubernetes_pod": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().Pods(ns).List(opts)
+	}),
+	"kubernetes_pod_disruption_budget": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.PolicyV1beta1().PodDisruptionBudgets(ns).List(opts)
+	}),
+	"kubernetes_replication_controller": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().ReplicationControllers(ns).List(opts)
+	}),
+	"kubernetes_resource_quota": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().ResourceQuotas(ns).List(opts)
+	}),
+	"kubernetes_role": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.RbacV1beta1().Roles(ns).List(opts)
+	}),
+	"kubernetes_secret": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().Secrets(ns).List(opts)
+	}),
+	"kubernetes_service": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.CoreV1().Services(ns).List(opts)
+	}),
+	"kubernetes_stateful_set": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.AppsV1beta1().StatefulSets(ns).List(opts)
+	}),
+	"kubernetes_storage_class": listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return conn.StorageV1().StorageClasses().List(opts)
+	}),
+}
+
+// listObjectMeta turns a List call into a resourceLister, extracting the
+// metadata of the listed objects.
+func listObjectMeta(list listFunc) resourceLister {
+	return func(conn *kubernetes.Clientset, namespace string, opts meta_v1.ListOptions) ([]meta_v1.ObjectMeta, error) {
+		out, err := list(conn, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(out)
+		if err != nil {
+			return nil, err
+		}
+		l := make([]meta_v1.ObjectMeta, 0, len(items))
+		for _, item := range items {
+			o, err := meta.Accessor(item)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, meta.AsPartialObjectMetadata(o).ObjectMeta)
+		}
+		return l, nil
+	}
+}
+
+// clusterScopedResourceTypes are imported by name only.
+var clusterScopedResourceTypes = map[string]bool{
+	"kubernetes_cluster_role":      true,
+	"kubernetes_namespace":         true,
+	"kubernetes_persistent_volume": true,
+	"kubernetes_storage_class":     true,
+}
+
+func dataSourceKubernetesResources() *schema.Resource {
+	var types []string
+	for t := range importableResourceListers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return &schema.Resource{
+		Read: dataSourceKubernetesResourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("Terraform resource type of the objects to list. One of %s.", strings.Join(types, ", ")),
+				Required:     true,
+				ValidateFunc: validateAttributeValueIsIn(types),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace to list the objects of. Ignored for cluster-scoped resource types.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"label_selector": {
+				Type:         schema.TypeString,
+				Description:  "A label query to filter the objects by, e.g. `app=web,tier!=cache`. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+				Optional:     true,
+				ValidateFunc: validateLabelSelector,
+			},
+			"include_owned": {
+				Type:        schema.TypeBool,
+				Description: "Whether to list objects owned by other objects, e.g. the pods of a deployment. They are managed through their owner, so they are skipped by default.",
+				Optional:    true,
+				Default:     false,
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "Names of the matching objects, sorted alphabetically.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Description: "IDs of the matching objects as expected by `terraform import`, in the same order as `names`.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesResourcesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	resourceType := d.Get("resource_type").(string)
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+	clusterScoped := clusterScopedResourceTypes[resourceType]
+	if clusterScoped {
+		namespace = ""
+	}
+
+	log.Printf("[INFO] Listing objects of %s in %q matching %q", resourceType, namespace, selector)
+	objects, err := importableResourceListers[resourceType](conn, namespace, meta_v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("Failed to list objects of %s: %s", resourceType, err)
+	}
+	log.Printf("[INFO] Received %d objects of %s", len(objects), resourceType)
+
+	names, ids := importIds(objects, clusterScoped, d.Get("include_owned").(bool))
+	err = d.Set("names", names)
+	if err != nil {
+		return err
+	}
+	err = d.Set("ids", ids)
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%d", hashcode.String(resourceType+"/"+namespace+"/"+selector+"/"+strings.Join(ids, ","))))
+
+	return nil
+}
+
+// importIds returns the sorted names of the objects and their IDs as expected
+// by the importers of the provider.
+func importIds(objects []meta_v1.ObjectMeta, clusterScoped, includeOwned bool) ([]string, []string) {
+	var filtered []meta_v1.ObjectMeta
+	for _, o := range objects {
+		if !includeOwned && len(o.OwnerReferences) > 0 {
+			continue
+		}
+		filtered = append(filtered, o)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+
+	names := make([]string, 0, len(filtered))
+	ids := make([]string, 0, len(filtered))
+	for _, o := range filtered {
+		names = append(names, o.Name)
+		if clusterScoped {
+			ids = append(ids, o.Name)
+		} else {
+			ids = append(ids, buildId(o))
+		}
+	}
+	return names, ids
+}
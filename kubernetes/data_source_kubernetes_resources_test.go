@@ -0,0 +1,170 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func TestAccKubernetesDataSourceResources_configMaps(t *testing.T) {
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceResourcesConfig_configMaps(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_resources.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_resources.test", "names.0", "a"),
+					resource.TestCheckResourceAttr("data.kubernetes_resources.test", "names.1", "b"),
+					resource.TestCheckResourceAttr("data.kubernetes_resources.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_resources.test", "ids.0", namespace+"/a"),
+					resource.TestCheckResourceAttr("data.kubernetes_resources.test", "ids.1", namespace+"/b"),
+				),
+			},
+		},
+	})
+}
+
+func TestImportIds(t *testing.T) {
+	objects := []meta_v1.ObjectMeta{
+		{Namespace: "web", Name: "frontend-5d8f7-x2k9p", OwnerReferences: []meta_v1.OwnerReference{{Kind: "ReplicaSet", Name: "frontend-5d8f7"}}},
+		{Namespace: "web", Name: "migrate"},
+		{Namespace: "web", Name: "debug"},
+	}
+
+	cases := []struct {
+		Name          string
+		ClusterScoped bool
+		IncludeOwned  bool
+		ExpectedNames []string
+		ExpectedIds   []string
+	}{
+		{
+			"namespaced",
+			false,
+			false,
+			[]string{"debug", "migrate"},
+			[]string{"web/debug", "web/migrate"},
+		},
+		{
+			"including owned objects",
+			false,
+			true,
+			[]string{"debug", "frontend-5d8f7-x2k9p", "migrate"},
+			[]string{"web/debug", "web/frontend-5d8f7-x2k9p", "web/migrate"},
+		},
+		{
+			"cluster scoped",
+			true,
+			false,
+			[]string{"debug", "migrate"},
+			[]string{"debug", "migrate"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			names, ids := importIds(objects, tc.ClusterScoped, tc.IncludeOwned)
+			if !reflect.DeepEqual(names, tc.ExpectedNames) {
+				t.Fatalf("Unexpected names.\nExpected: %#v\nGiven:    %#v", tc.ExpectedNames, names)
+			}
+			if !reflect.DeepEqual(ids, tc.ExpectedIds) {
+				t.Fatalf("Unexpected IDs.\nExpected: %#v\nGiven:    %#v", tc.ExpectedIds, ids)
+			}
+		})
+	}
+}
+
+func TestListObjectMeta(t *testing.T) {
+	owner := []meta_v1.OwnerReference{{Kind: "Deployment", Name: "web"}}
+	lister := listObjectMeta(func(conn *kubernetes.Clientset, ns string, opts meta_v1.ListOptions) (runtime.Object, error) {
+		return &api.ConfigMapList{Items: []api.ConfigMap{
+			{ObjectMeta: meta_v1.ObjectMeta{Namespace: ns, Name: "a"}},
+			{ObjectMeta: meta_v1.ObjectMeta{Namespace: ns, Name: "b", OwnerReferences: owner}},
+		}}, nil
+	})
+
+	out, err := lister(nil, "web", meta_v1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []meta_v1.ObjectMeta{
+		{Namespace: "web", Name: "a"},
+		{Namespace: "web", Name: "b", OwnerReferences: owner},
+	}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected objects.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestImportableResourceListers(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+	for resourceType := range importableResourceListers {
+		r, ok := resources[resourceType]
+		if !ok {
+			t.Fatalf("Lister of %s doesn't match a resource type", resourceType)
+		}
+		if r.Importer == nil {
+			t.Fatalf("Resource type %s can't be imported", resourceType)
+		}
+	}
+	// These don't manage whole objects, so there's nothing to list
+	partial := map[string]bool{
+		"kubernetes_default_image_pull_secret": true,
+		"kubernetes_service_status":            true,
+	}
+	for resourceType, r := range resources {
+		if r.Importer == nil || partial[resourceType] {
+			continue
+		}
+		if _, ok := importableResourceListers[resourceType]; !ok {
+			t.Fatalf("Importable %s has no lister", resourceType)
+		}
+	}
+	for resourceType := range clusterScopedResourceTypes {
+		if _, ok := importableResourceListers[resourceType]; !ok {
+			t.Fatalf("Cluster-scoped %s has no lister", resourceType)
+		}
+	}
+}
+
+func testAccKubernetesDataSourceResourcesConfig_configMaps(namespace string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_config_map" "a" {
+	metadata {
+		name      = "a"
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+}
+
+resource "kubernetes_config_map" "b" {
+	metadata {
+		name      = "b"
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+}
+
+data "kubernetes_resources" "test" {
+	resource_type = "kubernetes_config_map"
+	namespace     = "${kubernetes_namespace.test.metadata.0.name}"
+	depends_on    = ["kubernetes_config_map.a", "kubernetes_config_map.b"]
+}
+`, namespace)
+}
@@ -35,6 +35,16 @@ func dataSourceKubernetesService() *schema.Resource {
 							Description: "The external reference that kubedns or equivalent will return as a CNAME record for this service. No proxying will be involved. Must be a valid DNS name and requires `type` to be `ExternalName`.",
 							Computed:    true,
 						},
+						"external_traffic_policy": {
+							Type:        schema.TypeString,
+							Description: "Denotes if this service desires to route external traffic to node-local or cluster-wide endpoints. `Local` preserves the client source IP and avoids a second hop for `LoadBalancer` and `NodePort` type services, but risks potentially imbalanced traffic spreading. `Cluster` obscures the client source IP and may cause a second hop to another node, but should have good overall load-spreading. More info: https://kubernetes.io/docs/tutorials/services/source-ip/",
+							Computed:    true,
+						},
+						"health_check_node_port": {
+							Type:        schema.TypeInt,
+							Description: "The node port serving the health checks of the load balancer, when `external_traffic_policy` is `Local` and `type` is `LoadBalancer`.",
+							Computed:    true,
+						},
 						"load_balancer_ip": {
 							Type:        schema.TypeString,
 							Description: "Only applies to `type = LoadBalancer`. LoadBalancer will get created with the IP specified in this field. This feature depends on whether the underlying cloud-provider supports specifying this field when a load balancer is created. This field will be ignored if the cloud-provider does not support the feature.",
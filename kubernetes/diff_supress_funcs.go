@@ -1,6 +1,9 @@
 package kubernetes
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -16,3 +19,75 @@ func suppressEquivalentResourceQuantity(k, old, new string, d *schema.ResourceDa
 	}
 	return oldQ.Cmp(newQ) == 0
 }
+
+// suppressDefaultImagePullPolicy hides the difference between an unset
+// image_pull_policy and the value the API server defaults it to for the
+// container's image.
+func suppressDefaultImagePullPolicy(k, old, new string, d *schema.ResourceData) bool {
+	if old != "" && new != "" {
+		return false
+	}
+	image := d.Get(strings.TrimSuffix(k, "image_pull_policy") + "image").(string)
+	def := defaultImagePullPolicy(image)
+	return old == def || new == def
+}
+
+// suppressDefaultHostPort hides the difference between an unset host_port
+// and the container_port the API server defaults it to when the pod uses the
+// host's network.
+func suppressDefaultHostPort(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" && new != "0" {
+		return false
+	}
+	containerPort := d.Get(strings.TrimSuffix(k, "host_port") + "container_port").(int)
+	if old != strconv.Itoa(containerPort) {
+		return false
+	}
+	// Keys look like spec.0.container.0.port.0.host_port or
+	// spec.0.template.0.spec.0.init_container.0.port.0.host_port
+	podSpec := strings.TrimSuffix(k[:strings.LastIndex(k, "container.")], "init_")
+	hostNetwork, _ := d.Get(podSpec + "host_network").(bool)
+	return hostNetwork
+}
+
+// suppressDefaultSessionAffinity hides the difference between an empty
+// session_affinity, e.g. in the state of services created by older versions,
+// and `None` which the API server defaults it to.
+func suppressDefaultSessionAffinity(k, old, new string, d *schema.ResourceData) bool {
+	return (old == "" || old == "None") && (new == "" || new == "None")
+}
+
+// suppressDefaultProbeValue hides the difference between an unset probe
+// setting, e.g. in the state of pods imported or created by older versions,
+// and the value the API server defaults it to.
+func suppressDefaultProbeValue(def int) schema.SchemaDiffSuppressFunc {
+	d := strconv.Itoa(def)
+	return func(k, old, new string, _ *schema.ResourceData) bool {
+		isDefault := func(v string) bool {
+			return v == "" || v == "0" || v == d
+		}
+		return isDefault(old) && isDefault(new)
+	}
+}
+
+// suppressAfterCreation hides changes of attributes which only affect how a
+// resource is created, so that changing them doesn't replace existing ones.
+func suppressAfterCreation(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+// defaultImagePullPolicy mirrors the API server defaulting: images tagged
+// :latest, or not tagged at all, are always pulled.
+func defaultImagePullPolicy(image string) string {
+	if strings.Contains(image, "@") {
+		return "IfNotPresent"
+	}
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	if tag == "" || tag == "latest" {
+		return "Always"
+	}
+	return "IfNotPresent"
+}
@@ -0,0 +1,181 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestSuppressEquivalentResourceQuantity(t *testing.T) {
+	testCases := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"1Gi", "1024Mi", true},
+		{"1024Mi", "1Gi", true},
+		{"500m", "0.5", true},
+		{"1", "1000m", true},
+		{"128974848", "129e6", false},
+		{"1Gi", "1G", false},
+		{"1Gi", "2Gi", false},
+		{"", "1Gi", false},
+		{"1Gi", "invalid", false},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			suppress := suppressEquivalentResourceQuantity("spec.0.container.0.resources.0.limits.0.memory", tc.Old, tc.New, nil)
+			if suppress != tc.Suppress {
+				t.Fatalf("Expected suppression of %q -> %q to be %t", tc.Old, tc.New, tc.Suppress)
+			}
+		})
+	}
+}
+
+func TestDefaultImagePullPolicy(t *testing.T) {
+	testCases := []struct {
+		Image    string
+		Expected string
+	}{
+		{"nginx", "Always"},
+		{"nginx:latest", "Always"},
+		{"nginx:1.7.8", "IfNotPresent"},
+		{"localhost:5000/nginx", "Always"},
+		{"localhost:5000/nginx:latest", "Always"},
+		{"localhost:5000/nginx:1.7.8", "IfNotPresent"},
+		{"nginx@sha256:4ffd9758ea9ea360fd87d0cee7a2d1cf9dba630bb57ca36b3108dcd3708dc189", "IfNotPresent"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Image, func(t *testing.T) {
+			policy := defaultImagePullPolicy(tc.Image)
+			if policy != tc.Expected {
+				t.Fatalf("Expected pull policy %q for image %q, got %q", tc.Expected, tc.Image, policy)
+			}
+		})
+	}
+}
+
+func TestSuppressDefaultImagePullPolicy(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"image":             {Type: schema.TypeString, Optional: true},
+		"image_pull_policy": {Type: schema.TypeString, Optional: true, Computed: true},
+	}
+	testCases := []struct {
+		Image    string
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"nginx:latest", "Always", "", true},
+		{"nginx:latest", "", "Always", true},
+		{"nginx:latest", "IfNotPresent", "", false},
+		{"nginx:1.7.8", "IfNotPresent", "", true},
+		{"nginx:1.7.8", "Always", "", false},
+		{"nginx:1.7.8", "Always", "IfNotPresent", false},
+		{"nginx", "Always", "", true},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, map[string]interface{}{"image": tc.Image})
+			suppress := suppressDefaultImagePullPolicy("image_pull_policy", tc.Old, tc.New, d)
+			if suppress != tc.Suppress {
+				t.Fatalf("Expected suppression of %q -> %q for image %q to be %t", tc.Old, tc.New, tc.Image, tc.Suppress)
+			}
+		})
+	}
+}
+
+func TestSuppressDefaultSessionAffinity(t *testing.T) {
+	testCases := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"", "None", true},
+		{"None", "", true},
+		{"None", "None", true},
+		{"", "ClientIP", false},
+		{"None", "ClientIP", false},
+		{"ClientIP", "None", false},
+		{"ClientIP", "", false},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			suppress := suppressDefaultSessionAffinity("spec.0.session_affinity", tc.Old, tc.New, nil)
+			if suppress != tc.Suppress {
+				t.Fatalf("Expected suppression of %q -> %q to be %t", tc.Old, tc.New, tc.Suppress)
+			}
+		})
+	}
+}
+
+func TestSuppressDefaultProbeValue(t *testing.T) {
+	testCases := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"", "1", true},
+		{"0", "1", true},
+		{"1", "1", true},
+		{"0", "2", false},
+		{"2", "1", false},
+		{"1", "2", false},
+	}
+	suppressFunc := suppressDefaultProbeValue(1)
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			suppress := suppressFunc("spec.0.container.0.readiness_probe.0.success_threshold", tc.Old, tc.New, nil)
+			if suppress != tc.Suppress {
+				t.Fatalf("Expected suppression of %q -> %q to be %t", tc.Old, tc.New, tc.Suppress)
+			}
+		})
+	}
+}
+
+func TestSuppressDefaultHostPort(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: podSpecFields(true),
+			},
+		},
+	}
+	testCases := []struct {
+		Key         string
+		HostNetwork bool
+		Old         string
+		New         string
+		Suppress    bool
+	}{
+		{"spec.0.container.0.port.0.host_port", true, "8080", "0", true},
+		{"spec.0.container.0.port.0.host_port", true, "8080", "", true},
+		{"spec.0.init_container.0.port.0.host_port", true, "8080", "0", true},
+		{"spec.0.container.0.port.0.host_port", false, "8080", "0", false},
+		{"spec.0.container.0.port.0.host_port", true, "9090", "0", false},
+		{"spec.0.container.0.port.0.host_port", true, "8080", "9090", false},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			container := map[string]interface{}{
+				"name":  "web",
+				"image": "nginx:1.7.8",
+				"port":  []interface{}{map[string]interface{}{"container_port": 8080}},
+			}
+			d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+				"spec": []interface{}{map[string]interface{}{
+					"host_network":   tc.HostNetwork,
+					"container":      []interface{}{container},
+					"init_container": []interface{}{container},
+				}},
+			})
+			suppress := suppressDefaultHostPort(tc.Key, tc.Old, tc.New, d)
+			if suppress != tc.Suppress {
+				t.Fatalf("Expected suppression of %q -> %q for %s to be %t", tc.Old, tc.New, tc.Key, tc.Suppress)
+			}
+		})
+	}
+}
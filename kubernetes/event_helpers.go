@@ -29,21 +29,42 @@ func getLastWarningsForObject(conn *kubernetes.Clientset, metadata meta_v1.Objec
 		return nil, err
 	}
 
+	log.Printf("[DEBUG] Received %d events for %s/%s (%s)",
+		len(out.Items), metadata.Namespace, metadata.Name, kind)
+
+	return lastUniqueWarnings(out.Items, limit), nil
+}
+
+// getLastWarningsInNamespace returns the latest warnings of any object in the
+// namespace, for failures which may be caused by other objects (e.g. a missing
+// secret or an exhausted quota).
+func getLastWarningsInNamespace(conn *kubernetes.Clientset, namespace string, limit int) ([]api.Event, error) {
+	fs := fields.Set{"type": api.EventTypeWarning}.String()
+	log.Printf("[DEBUG] Looking up events in %q via this selector: %q", namespace, fs)
+	out, err := conn.CoreV1().Events(namespace).List(meta_v1.ListOptions{
+		FieldSelector: fs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Received %d warnings in %s", len(out.Items), namespace)
+
+	return lastUniqueWarnings(out.Items, limit), nil
+}
+
+func lastUniqueWarnings(events []api.Event, limit int) []api.Event {
 	// It would be better to sort & filter on the server-side
 	// but API doesn't seem to support it
 	var warnings []api.Event
 
 	// Bring latest events to the top, for easy access
-	sort.Slice(out.Items, func(i, j int) bool {
-		return out.Items[i].LastTimestamp.After(out.Items[j].LastTimestamp.Time)
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.After(events[j].LastTimestamp.Time)
 	})
 
-	log.Printf("[DEBUG] Received %d events for %s/%s (%s)",
-		len(out.Items), metadata.Namespace, metadata.Name, kind)
-
 	warnCount := 0
 	uniqueWarnings := make(map[string]api.Event, 0)
-	for _, e := range out.Items {
+	for _, e := range events {
 		if warnCount >= limit {
 			break
 		}
@@ -59,7 +80,7 @@ func getLastWarningsForObject(conn *kubernetes.Clientset, metadata meta_v1.Objec
 		}
 	}
 
-	return warnings, nil
+	return warnings
 }
 
 func stringifyEvents(events []api.Event) string {
@@ -0,0 +1,207 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// serverPopulatedMetadataFields are owned by the API server. They are dropped
+// from submitted manifests so they are neither sent nor compared.
+var serverPopulatedMetadataFields = []string{
+	"creationTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// parseManifest decodes a YAML or JSON manifest into an unstructured object,
+// without its status and server-populated metadata.
+func parseManifest(manifest string) (map[string]interface{}, error) {
+	b, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse manifest: %s", err)
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, fmt.Errorf("Failed to parse manifest: %s", err)
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("Manifest is empty")
+	}
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, f := range serverPopulatedMetadataFields {
+			delete(metadata, f)
+		}
+	}
+	return obj, nil
+}
+
+// normalizeManifest returns the canonical JSON of a manifest, so that
+// formatting and key order don't cause diffs.
+func normalizeManifest(manifest string) (string, error) {
+	obj, err := parseManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func normalizeManifestStateFunc(v interface{}) string {
+	s, err := normalizeManifest(v.(string))
+	if err != nil {
+		// Left to validateManifest to report
+		return v.(string)
+	}
+	return s
+}
+
+func validateManifest(value interface{}, key string) (ws []string, es []error) {
+	obj, err := parseManifest(value.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: %s", key, err))
+		return
+	}
+	apiVersion, kind, _, name := manifestIdentity(obj)
+	if apiVersion == "" {
+		es = append(es, fmt.Errorf("%s: apiVersion must be set", key))
+	}
+	if kind == "" {
+		es = append(es, fmt.Errorf("%s: kind must be set", key))
+	}
+	if name == "" {
+		es = append(es, fmt.Errorf("%s: metadata.name must be set", key))
+	}
+	return
+}
+
+// manifestIdentity returns the fields identifying the object of a manifest.
+func manifestIdentity(obj map[string]interface{}) (apiVersion, kind, namespace, name string) {
+	apiVersion, _ = obj["apiVersion"].(string)
+	kind, _ = obj["kind"].(string)
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		namespace, _ = metadata["namespace"].(string)
+		name, _ = metadata["name"].(string)
+	}
+	return
+}
+
+// manifestIdentityChanged tells whether the old and new manifests describe
+// different objects, which can only be replaced.
+func manifestIdentityChanged(d *schema.ResourceDiff) bool {
+	o, n := d.GetChange("manifest")
+	oldObj, err := parseManifest(o.(string))
+	if err != nil {
+		return false
+	}
+	newObj, err := parseManifest(n.(string))
+	if err != nil {
+		return false
+	}
+	oldVersion, oldKind, oldNamespace, oldName := manifestIdentity(oldObj)
+	newVersion, newKind, newNamespace, newName := manifestIdentity(newObj)
+	return oldVersion != newVersion || oldKind != newKind || oldNamespace != newNamespace || oldName != newName
+}
+
+// manifestCollectionPath discovers the resource serving the kind of the
+// manifest and returns the API path of its collection.
+func manifestCollectionPath(conn *kubernetes.Clientset, obj map[string]interface{}) (string, error) {
+	apiVersion, kind, namespace, _ := manifestIdentity(obj)
+	resources, err := conn.Discovery().ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		return "", fmt.Errorf("Failed to discover the resources of %s: %s", apiVersion, err)
+	}
+	for _, r := range resources.APIResources {
+		// Subresources, e.g. deployments/scale, share the kind of their parent
+		if r.Kind != kind || strings.Contains(r.Name, "/") {
+			continue
+		}
+		if !r.Namespaced {
+			namespace = ""
+		} else if namespace == "" {
+			namespace = "default"
+		}
+		return buildManifestCollectionPath(apiVersion, namespace, r.Name), nil
+	}
+	return "", fmt.Errorf("The server doesn't serve kind %s of %s", kind, apiVersion)
+}
+
+func buildManifestCollectionPath(apiVersion, namespace, resource string) string {
+	path := "/apis/" + apiVersion
+	if !strings.Contains(apiVersion, "/") {
+		// The core group, e.g. v1
+		path = "/api/" + apiVersion
+	}
+	if namespace != "" {
+		path += "/namespaces/" + namespace
+	}
+	return path + "/" + resource
+}
+
+// projectManifest returns the remote object reduced to the fields set in the
+// submitted manifest, so that defaults and status written by the server are
+// ignored while changes to the submitted fields show up as a diff.
+func projectManifest(remote, submitted interface{}) interface{} {
+	switch s := submitted.(type) {
+	case map[string]interface{}:
+		r, ok := remote.(map[string]interface{})
+		if !ok {
+			return remote
+		}
+		out := make(map[string]interface{}, len(s))
+		for k, v := range s {
+			if rv, ok := r[k]; ok {
+				out[k] = projectManifest(rv, v)
+			}
+		}
+		return out
+	case []interface{}:
+		r, ok := remote.([]interface{})
+		if !ok || len(r) != len(s) {
+			return remote
+		}
+		out := make([]interface{}, len(s))
+		for i := range s {
+			out[i] = projectManifest(r[i], s[i])
+		}
+		return out
+	}
+	return remote
+}
+
+// manifestMergePatch returns the JSON merge patch (RFC 7386) turning the old
+// manifest into the new one. Fields removed from the manifest are nulled.
+func manifestMergePatch(oldObj, newObj map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k := range oldObj {
+		if _, ok := newObj[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, n := range newObj {
+		o, ok := oldObj[k]
+		if ok && reflect.DeepEqual(o, n) {
+			continue
+		}
+		om, oldIsMap := o.(map[string]interface{})
+		nm, newIsMap := n.(map[string]interface{})
+		if oldIsMap && newIsMap {
+			patch[k] = manifestMergePatch(om, nm)
+			continue
+		}
+		patch[k] = n
+	}
+	return patch
+}
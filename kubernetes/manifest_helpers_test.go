@@ -0,0 +1,158 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeManifest(t *testing.T) {
+	yamlManifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  resourceVersion: "42"
+  uid: 7d3f6c0e-0000-11e8-8c4b-42010a840002
+data:
+  replicas: "3"
+status:
+  phase: Active
+`
+	jsonManifest := `{"kind": "ConfigMap", "data": {"replicas": "3"}, "metadata": {"name": "app-config"}, "apiVersion": "v1"}`
+	expected := `{"apiVersion":"v1","data":{"replicas":"3"},"kind":"ConfigMap","metadata":{"name":"app-config"}}`
+
+	for _, m := range []string{yamlManifest, jsonManifest} {
+		out, err := normalizeManifest(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out != expected {
+			t.Fatalf("Unexpected normalized manifest.\nExpected: %s\nGiven:    %s", expected, out)
+		}
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Input  string
+		Errors int
+	}{
+		{"valid", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n", 0},
+		{"empty", "", 1},
+		{"not an object", "- apiVersion: v1\n", 1},
+		{"without identity", "data:\n  a: b\n", 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, es := validateManifest(tc.Input, "manifest")
+			if len(es) != tc.Errors {
+				t.Fatalf("Expected %d errors, given %d: %v", tc.Errors, len(es), es)
+			}
+		})
+	}
+}
+
+func TestBuildManifestCollectionPath(t *testing.T) {
+	cases := []struct {
+		APIVersion string
+		Namespace  string
+		Resource   string
+		Expected   string
+	}{
+		{"v1", "default", "configmaps", "/api/v1/namespaces/default/configmaps"},
+		{"v1", "", "namespaces", "/api/v1/namespaces"},
+		{"stable.example.com/v1", "apps", "crontabs", "/apis/stable.example.com/v1/namespaces/apps/crontabs"},
+		{"apiextensions.k8s.io/v1beta1", "", "customresourcedefinitions", "/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			out := buildManifestCollectionPath(tc.APIVersion, tc.Namespace, tc.Resource)
+			if out != tc.Expected {
+				t.Fatalf("Expected %q, given %q", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestProjectManifest(t *testing.T) {
+	remote := decodeTestJSON(t, `{
+  "apiVersion": "v1",
+  "kind": "Service",
+  "metadata": {"name": "web", "namespace": "default", "uid": "abc", "resourceVersion": "42"},
+  "spec": {
+    "clusterIP": "10.0.0.12",
+    "type": "ClusterIP",
+    "ports": [{"name": "http", "port": 80, "protocol": "TCP", "targetPort": 8080}],
+    "selector": {"app": "web"}
+  },
+  "status": {"loadBalancer": {}}
+}`)
+	submitted := decodeTestJSON(t, `{
+  "apiVersion": "v1",
+  "kind": "Service",
+  "metadata": {"name": "web", "labels": {"team": "web"}},
+  "spec": {
+    "ports": [{"port": 80, "targetPort": 8080}],
+    "selector": {"app": "web"}
+  }
+}`)
+	// The labels were removed from the remote object, so they are left out to show up as a diff
+	expected := decodeTestJSON(t, `{
+  "apiVersion": "v1",
+  "kind": "Service",
+  "metadata": {"name": "web"},
+  "spec": {
+    "ports": [{"port": 80, "targetPort": 8080}],
+    "selector": {"app": "web"}
+  }
+}`)
+
+	out := projectManifest(remote, submitted)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected projected manifest.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+
+	// Lists of another length are kept whole
+	submitted["spec"].(map[string]interface{})["ports"] = []interface{}{}
+	out = projectManifest(remote, submitted)
+	ports := out.(map[string]interface{})["spec"].(map[string]interface{})["ports"]
+	if !reflect.DeepEqual(ports, remote["spec"].(map[string]interface{})["ports"]) {
+		t.Fatalf("Expected the remote ports to be kept, given %#v", ports)
+	}
+}
+
+func TestManifestMergePatch(t *testing.T) {
+	oldObj := decodeTestJSON(t, `{
+  "apiVersion": "v1",
+  "kind": "ConfigMap",
+  "metadata": {"name": "app-config", "labels": {"team": "web", "tier": "frontend"}},
+  "data": {"replicas": "3", "debug": "true"}
+}`)
+	newObj := decodeTestJSON(t, `{
+  "apiVersion": "v1",
+  "kind": "ConfigMap",
+  "metadata": {"name": "app-config", "labels": {"team": "web"}},
+  "data": {"replicas": "5", "debug": "true", "region": "eu"}
+}`)
+	expected := decodeTestJSON(t, `{
+  "metadata": {"labels": {"tier": null}},
+  "data": {"replicas": "5", "region": "eu"}
+}`)
+
+	out := manifestMergePatch(oldObj, newObj)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected merge patch.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func decodeTestJSON(t *testing.T, s string) map[string]interface{} {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
@@ -0,0 +1,293 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// deploymentRevisionAnnotation is set by the deployment controller on both
+// the deployment and its replica sets to tell which revision they belong to.
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// listPods lists the pods matching the label selector. The field selector
+// (e.g. `status.phase!=Succeeded`) is optional and lets the API server filter
+// the pods, which keeps the response small on large namespaces.
+func listPods(conn *kubernetes.Clientset, namespace string, selector *meta_v1.LabelSelector, fieldSelector string) ([]api.Pod, error) {
+	opts := meta_v1.ListOptions{
+		LabelSelector: meta_v1.FormatLabelSelector(selector),
+		FieldSelector: fieldSelector,
+	}
+	log.Printf("[DEBUG] Listing pods in %q matching %q and %q", namespace, opts.LabelSelector, opts.FieldSelector)
+	out, err := conn.CoreV1().Pods(namespace).List(opts)
+	if err != nil {
+		return nil, err
+	}
+	return out.Items, nil
+}
+
+// podPhaseNotInFieldSelector returns a field selector excluding pods in any
+// of the given phases.
+func podPhaseNotInFieldSelector(phases ...api.PodPhase) string {
+	terms := make([]string, 0, len(phases))
+	for _, p := range phases {
+		terms = append(terms, fmt.Sprintf("status.phase!=%s", p))
+	}
+	return strings.Join(terms, ",")
+}
+
+// newReplicaSet returns the replica set of the current revision of the
+// deployment, or nil if the controller didn't create it yet.
+func newReplicaSet(conn *kubernetes.Clientset, deployment *v1beta1.Deployment) (*v1beta1.ReplicaSet, error) {
+	rsList, err := conn.ExtensionsV1beta1().ReplicaSets(deployment.Namespace).List(meta_v1.ListOptions{
+		LabelSelector: meta_v1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newReplicaSetFrom(deployment, rsList.Items), nil
+}
+
+func newReplicaSetFrom(deployment *v1beta1.Deployment, replicaSets []v1beta1.ReplicaSet) *v1beta1.ReplicaSet {
+	revision, ok := deployment.Annotations[deploymentRevisionAnnotation]
+	if !ok {
+		return nil
+	}
+	for i, rs := range replicaSets {
+		if rs.Annotations[deploymentRevisionAnnotation] == revision {
+			return &replicaSets[i]
+		}
+	}
+	return nil
+}
+
+// newReplicaSetPodSelector returns the selector of the pods belonging to the
+// current revision of the deployment, or nil if the controller didn't create
+// its replica set yet.
+func newReplicaSetPodSelector(conn *kubernetes.Clientset, deployment *v1beta1.Deployment) (*meta_v1.LabelSelector, error) {
+	rsList, err := conn.ExtensionsV1beta1().ReplicaSets(deployment.Namespace).List(meta_v1.ListOptions{
+		LabelSelector: meta_v1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newReplicaSetPodSelectorFrom(deployment, rsList.Items), nil
+}
+
+func newReplicaSetPodSelectorFrom(deployment *v1beta1.Deployment, replicaSets []v1beta1.ReplicaSet) *meta_v1.LabelSelector {
+	rs := newReplicaSetFrom(deployment, replicaSets)
+	if rs == nil {
+		return nil
+	}
+	hash, ok := rs.Labels[v1beta1.DefaultDeploymentUniqueLabelKey]
+	if !ok {
+		return nil
+	}
+
+	selector := &meta_v1.LabelSelector{
+		MatchLabels: map[string]string{
+			v1beta1.DefaultDeploymentUniqueLabelKey: hash,
+		},
+	}
+	if deployment.Spec.Selector != nil {
+		for k, v := range deployment.Spec.Selector.MatchLabels {
+			selector.MatchLabels[k] = v
+		}
+		selector.MatchExpressions = deployment.Spec.Selector.MatchExpressions
+	}
+	return selector
+}
+
+// effectiveImagePullSecrets returns the names of the pull secrets available
+// to pods running as the given service account: the kubelet uses both the
+// secrets of the pod and the ones of its service account. It returns false if
+// the service account doesn't exist yet.
+func effectiveImagePullSecrets(conn *kubernetes.Clientset, namespace, serviceAccountName string, podSecrets []api.LocalObjectReference) ([]string, bool, error) {
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	sa, err := conn.CoreV1().ServiceAccounts(namespace).Get(serviceAccountName, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("Failed to read service account %s/%s: %s", namespace, serviceAccountName, err)
+	}
+	return mergeImagePullSecrets(podSecrets, sa.ImagePullSecrets), true, nil
+}
+
+func mergeImagePullSecrets(podSecrets, serviceAccountSecrets []api.LocalObjectReference) []string {
+	names := make(map[string]bool)
+	for _, secrets := range [][]api.LocalObjectReference{podSecrets, serviceAccountSecrets} {
+		for _, s := range secrets {
+			if s.Name != "" {
+				names[s.Name] = true
+			}
+		}
+	}
+	out := make([]string, 0, len(names))
+	for name := range names {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// unschedulablePodsDiagnostics describes why up to limit pods matching the
+// selector can't be scheduled, using their last FailedScheduling warning. When
+// an init container requests more of a resource than all the containers
+// together, the scheduler's "Insufficient cpu" is easily misread, so it is
+// pointed out as well.
+func unschedulablePodsDiagnostics(conn *kubernetes.Clientset, namespace string, selector *meta_v1.LabelSelector, limit int) (string, error) {
+	pods, err := listPods(conn, namespace, selector, fmt.Sprintf("status.phase=%s", api.PodPending))
+	if err != nil {
+		return "", err
+	}
+
+	var output string
+	count := 0
+	for _, pod := range pods {
+		if count >= limit {
+			break
+		}
+		cond, ok := podUnschedulableCondition(pod)
+		if !ok {
+			continue
+		}
+		count++
+
+		warnings, err := getLastWarningsForObject(conn, pod.ObjectMeta, "Pod", 1)
+		if err != nil {
+			return "", err
+		}
+		if len(warnings) > 0 {
+			output += stringifyEvents(warnings)
+		} else {
+			// Events expire, the condition is kept as long as the pod is pending
+			output += fmt.Sprintf("\n   * %s (Pod): %s: %s", pod.Name, cond.Reason, cond.Message)
+		}
+		for _, r := range initContainerDominatedRequests(pod.Spec) {
+			output += fmt.Sprintf("\n     %s", r)
+		}
+	}
+	return output, nil
+}
+
+// failingPodsDiagnostics describes why the containers of up to limit pods
+// matching the selector aren't running, e.g. CrashLoopBackOff or
+// ImagePullBackOff.
+func failingPodsDiagnostics(conn *kubernetes.Clientset, namespace string, selector *meta_v1.LabelSelector, limit int) (string, error) {
+	pods, err := listPods(conn, namespace, selector, podPhaseNotInFieldSelector(api.PodSucceeded))
+	if err != nil {
+		return "", err
+	}
+
+	var output string
+	count := 0
+	for _, pod := range pods {
+		if count >= limit {
+			break
+		}
+		failures := podContainerFailures(pod)
+		if len(failures) == 0 {
+			continue
+		}
+		count++
+		for _, f := range failures {
+			output += fmt.Sprintf("\n   * %s (Pod): %s", pod.Name, f)
+		}
+	}
+	return output, nil
+}
+
+// podContainerFailures lists the containers of the pod which are waiting for
+// another reason than being started, or which were restarted after failing.
+func podContainerFailures(pod api.Pod) []string {
+	var out []string
+	statuses := append(append([]api.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		waiting := cs.State.Waiting
+		if waiting == nil || waiting.Reason == "ContainerCreating" || waiting.Reason == "PodInitializing" {
+			continue
+		}
+		failure := fmt.Sprintf("container %q is waiting: %s", cs.Name, waiting.Reason)
+		if waiting.Message != "" {
+			failure += ": " + waiting.Message
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil {
+			failure += fmt.Sprintf(" (last exit code %d", t.ExitCode)
+			if t.Reason != "" {
+				failure += ", " + t.Reason
+			}
+			failure += fmt.Sprintf(", %d restarts)", cs.RestartCount)
+		}
+		out = append(out, failure)
+	}
+	return out
+}
+
+func podUnschedulableCondition(pod api.Pod) (api.PodCondition, bool) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == api.PodScheduled && c.Status == api.ConditionFalse && c.Reason == api.PodReasonUnschedulable {
+			return c, true
+		}
+	}
+	return api.PodCondition{}, false
+}
+
+// initContainerDominatedRequests lists the resources for which an init
+// container determines the pod's request. Init containers run one at a time
+// before the containers start, so the scheduler reserves the larger of the
+// highest init container request and the sum of the container requests.
+func initContainerDominatedRequests(spec api.PodSpec) []string {
+	sums := api.ResourceList{}
+	for _, c := range spec.Containers {
+		for name, q := range c.Resources.Requests {
+			sum, ok := sums[name]
+			if !ok {
+				sums[name] = q.DeepCopy()
+				continue
+			}
+			sum.Add(q)
+			sums[name] = sum
+		}
+	}
+
+	highest := api.ResourceList{}
+	highestBy := map[api.ResourceName]string{}
+	for _, c := range spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			h, ok := highest[name]
+			if !ok || q.Cmp(h) > 0 {
+				highest[name] = q.DeepCopy()
+				highestBy[name] = c.Name
+			}
+		}
+	}
+
+	names := make([]string, 0, len(highest))
+	for name := range highest {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var out []string
+	for _, n := range names {
+		name := api.ResourceName(n)
+		h := highest[name]
+		sum := sums[name]
+		if h.Cmp(sum) <= 0 {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s request of %s is set by init container %q (containers request %s in total)",
+			name, h.String(), highestBy[name], sum.String()))
+	}
+	return out
+}
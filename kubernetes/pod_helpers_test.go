@@ -0,0 +1,222 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func TestPodPhaseNotInFieldSelector(t *testing.T) {
+	out := podPhaseNotInFieldSelector(api.PodRunning, api.PodSucceeded)
+	expected := "status.phase!=Running,status.phase!=Succeeded"
+	if out != expected {
+		t.Fatalf("Expected %q, got %q", expected, out)
+	}
+}
+
+func TestNewReplicaSetPodSelectorFrom(t *testing.T) {
+	replicaSet := func(revision, hash string) v1beta1.ReplicaSet {
+		return v1beta1.ReplicaSet{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Annotations: map[string]string{deploymentRevisionAnnotation: revision},
+				Labels:      map[string]string{"app": "web", v1beta1.DefaultDeploymentUniqueLabelKey: hash},
+			},
+		}
+	}
+	deployment := func(revision string) *v1beta1.Deployment {
+		d := &v1beta1.Deployment{
+			Spec: v1beta1.DeploymentSpec{
+				Selector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+		}
+		if revision != "" {
+			d.Annotations = map[string]string{deploymentRevisionAnnotation: revision}
+		}
+		return d
+	}
+	replicaSets := []v1beta1.ReplicaSet{replicaSet("1", "1111"), replicaSet("2", "2222")}
+
+	cases := []struct {
+		Name       string
+		Deployment *v1beta1.Deployment
+		Expected   *meta_v1.LabelSelector
+	}{
+		{
+			"current revision",
+			deployment("2"),
+			&meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web", "pod-template-hash": "2222"}},
+		},
+		{
+			"replica set not created yet",
+			deployment("3"),
+			nil,
+		},
+		{
+			"revision not observed yet",
+			deployment(""),
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := newReplicaSetPodSelectorFrom(tc.Deployment, replicaSets)
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected selector.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestMergeImagePullSecrets(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Pod            []api.LocalObjectReference
+		ServiceAccount []api.LocalObjectReference
+		Expected       []string
+	}{
+		{
+			"none",
+			nil,
+			nil,
+			[]string{},
+		},
+		{
+			"service account only",
+			nil,
+			[]api.LocalObjectReference{{Name: "registry"}},
+			[]string{"registry"},
+		},
+		{
+			"union without duplicates",
+			[]api.LocalObjectReference{{Name: "team-registry"}, {Name: "registry"}},
+			[]api.LocalObjectReference{{Name: "registry"}, {Name: "docker-hub"}},
+			[]string{"docker-hub", "registry", "team-registry"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := mergeImagePullSecrets(tc.Pod, tc.ServiceAccount)
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected pull secrets.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestInitContainerDominatedRequests(t *testing.T) {
+	container := func(name string, requests map[api.ResourceName]string) api.Container {
+		c := api.Container{Name: name, Resources: api.ResourceRequirements{Requests: api.ResourceList{}}}
+		for k, v := range requests {
+			c.Resources.Requests[k] = resource.MustParse(v)
+		}
+		return c
+	}
+
+	cases := []struct {
+		Name     string
+		Spec     api.PodSpec
+		Expected []string
+	}{
+		{
+			"no init containers",
+			api.PodSpec{
+				Containers: []api.Container{container("app", map[api.ResourceName]string{api.ResourceCPU: "500m"})},
+			},
+			nil,
+		},
+		{
+			"containers request more in total",
+			api.PodSpec{
+				InitContainers: []api.Container{container("migrate", map[api.ResourceName]string{api.ResourceCPU: "800m"})},
+				Containers: []api.Container{
+					container("app", map[api.ResourceName]string{api.ResourceCPU: "500m"}),
+					container("sidecar", map[api.ResourceName]string{api.ResourceCPU: "500m"}),
+				},
+			},
+			nil,
+		},
+		{
+			"init container requests more",
+			api.PodSpec{
+				InitContainers: []api.Container{
+					container("fetch", map[api.ResourceName]string{api.ResourceCPU: "100m"}),
+					container("migrate", map[api.ResourceName]string{api.ResourceCPU: "2", api.ResourceMemory: "1Gi"}),
+				},
+				Containers: []api.Container{container("app", map[api.ResourceName]string{api.ResourceCPU: "500m"})},
+			},
+			[]string{
+				`cpu request of 2 is set by init container "migrate" (containers request 500m in total)`,
+				`memory request of 1Gi is set by init container "migrate" (containers request 0 in total)`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := initContainerDominatedRequests(tc.Spec)
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected requests.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestPodContainerFailures(t *testing.T) {
+	waiting := func(name, reason, message string) api.ContainerStatus {
+		return api.ContainerStatus{
+			Name:  name,
+			State: api.ContainerState{Waiting: &api.ContainerStateWaiting{Reason: reason, Message: message}},
+		}
+	}
+	crashLooping := waiting("app", "CrashLoopBackOff", "Back-off 5m0s restarting failed container")
+	crashLooping.RestartCount = 7
+	crashLooping.LastTerminationState = api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}
+
+	cases := []struct {
+		Name     string
+		Status   api.PodStatus
+		Expected []string
+	}{
+		{
+			"running",
+			api.PodStatus{ContainerStatuses: []api.ContainerStatus{
+				{Name: "app", State: api.ContainerState{Running: &api.ContainerStateRunning{}}},
+			}},
+			nil,
+		},
+		{
+			"starting",
+			api.PodStatus{
+				InitContainerStatuses: []api.ContainerStatus{waiting("migrate", "PodInitializing", "")},
+				ContainerStatuses:     []api.ContainerStatus{waiting("app", "ContainerCreating", "")},
+			},
+			nil,
+		},
+		{
+			"failing",
+			api.PodStatus{
+				InitContainerStatuses: []api.ContainerStatus{waiting("fetch", "ImagePullBackOff", `Back-off pulling image "fetch:missing"`)},
+				ContainerStatuses:     []api.ContainerStatus{crashLooping},
+			},
+			[]string{
+				`container "fetch" is waiting: ImagePullBackOff: Back-off pulling image "fetch:missing"`,
+				`container "app" is waiting: CrashLoopBackOff: Back-off 5m0s restarting failed container (last exit code 1, Error, 7 restarts)`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := podContainerFailures(api.Pod{Status: tc.Status})
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected failures.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
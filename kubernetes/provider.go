@@ -3,13 +3,23 @@ package kubernetes
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-homedir"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	kubernetes "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -17,7 +27,7 @@ import (
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -76,18 +86,19 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_CTX", ""),
+				Description: "Context to choose from the config file, defaults to its current context",
 			},
 			"config_context_auth_info": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_CTX_AUTH_INFO", ""),
-				Description: "",
+				Description: "Name of the config file user to use instead of the one of the context",
 			},
 			"config_context_cluster": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_CTX_CLUSTER", ""),
-				Description: "",
+				Description: "Name of the config file cluster to use instead of the one of the context",
 			},
 			"token": {
 				Type:        schema.TypeString,
@@ -95,41 +106,196 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_TOKEN", ""),
 				Description: "Token to authentifcate an service account",
 			},
+			"token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("KUBE_TOKEN_FILE", ""),
+				ConflictsWith: []string{"token"},
+				Description:   "Path to a file holding the bearer token, e.g. a projected service account token. The file is read again whenever it changes.",
+			},
 			"load_config_file": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_LOAD_CONFIG_FILE", true),
 				Description: "Load local kubeconfig.",
 			},
+			"ping_on_configure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_PING_ON_CONFIGURE", false),
+				Description: "Verify that the Kubernetes API server is reachable and the credentials are valid when the provider is configured.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KUBE_PROXY_URL", ""),
+				ValidateFunc: validateProxyURL,
+				Description:  "URL of the HTTP(S) or SOCKS5 proxy to reach the Kubernetes API server through, e.g. `http://proxy.example.com:3128`.",
+			},
+			"check_namespace_exists": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CHECK_NAMESPACE_EXISTS", false),
+				Description: "Check that the namespace of a namespaced resource exists before creating it, to fail with a clear error when it doesn't.",
+			},
+			"create_missing_namespaces": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CREATE_MISSING_NAMESPACES", false),
+				Description: "Create the namespace of a namespaced resource when it doesn't exist yet. The namespace isn't managed by Terraform afterwards.",
+			},
+			"validate_env_var_references": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_VALIDATE_ENV_VAR_REFERENCES", false),
+				Description: "Fail the plan when a container's `command`, `args` or `env` reference a `$(VAR)` that isn't defined earlier in the container's `env`. Kubernetes leaves such references unexpanded.",
+			},
+			"validate_termination_grace_period": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_VALIDATE_TERMINATION_GRACE_PERIOD", false),
+				Description: "Fail the plan when the `sleep` of a container's `pre_stop` hook plus the time its readiness probe needs to fail exceed the pod's `termination_grace_period_seconds`.",
+			},
+			"validate_read_only_root_filesystem": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_VALIDATE_READ_ONLY_ROOT_FILESYSTEM", false),
+				Description: "Fail the plan when a container with a read-only root filesystem has no writable volume, e.g. an `empty_dir`, mounted at paths applications commonly write to, like `/tmp`.",
+			},
+			"validate_stateful_set_service": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_VALIDATE_STATEFUL_SET_SERVICE", false),
+				Description: "Fail the plan when the `service_name` of a stateful set is an existing service in its namespace that isn't headless. A missing service is only logged as a warning.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"kubernetes_service":       dataSourceKubernetesService(),
-			"kubernetes_storage_class": dataSourceKubernetesStorageClass(),
+			"kubernetes_all_namespaces": dataSourceKubernetesAllNamespaces(),
+			"kubernetes_deployment":     dataSourceKubernetesDeployment(),
+			"kubernetes_pod_logs":       dataSourceKubernetesPodLogs(),
+			"kubernetes_resources":      dataSourceKubernetesResources(),
+			"kubernetes_service":        dataSourceKubernetesService(),
+			"kubernetes_storage_class":  dataSourceKubernetesStorageClass(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"kubernetes_cluster_role":              resourceKubernetesClusterRole(),
 			"kubernetes_config_map":                resourceKubernetesConfigMap(),
 			"kubernetes_horizontal_pod_autoscaler": resourceKubernetesHorizontalPodAutoscaler(),
 			"kubernetes_job":                       resourceKubernetesJob(),
 			"kubernetes_ingress":                   resourceKubernetesIngress(),
 			"kubernetes_limit_range":               resourceKubernetesLimitRange(),
+			"kubernetes_manifest":                  resourceKubernetesManifest(),
 			"kubernetes_namespace":                 resourceKubernetesNamespace(),
 			"kubernetes_persistent_volume":         resourceKubernetesPersistentVolume(),
 			"kubernetes_persistent_volume_claim":   resourceKubernetesPersistentVolumeClaim(),
 			"kubernetes_pod":                       resourceKubernetesPod(),
+			"kubernetes_pod_disruption_budget":     resourceKubernetesPodDisruptionBudget(),
 			"kubernetes_replication_controller":    resourceKubernetesReplicationController(),
 			"kubernetes_deployment":                resourceKubernetesDeployment(),
+			"kubernetes_default_image_pull_secret": resourceKubernetesDefaultImagePullSecret(),
+			"kubernetes_endpoints":                 resourceKubernetesEndpoints(),
 			"kubernetes_daemonset":                 resourceKubernetesDaemonSet(),
 			"kubernetes_resource_quota":            resourceKubernetesResourceQuota(),
+			"kubernetes_role":                      resourceKubernetesRole(),
 			"kubernetes_secret":                    resourceKubernetesSecret(),
 			"kubernetes_service":                   resourceKubernetesService(),
+			"kubernetes_service_status":            resourceKubernetesServiceStatus(),
 			"kubernetes_service_account":           resourceKubernetesServiceAccount(),
 			"kubernetes_stateful_set":              resourceKubernetesStatefulSet(),
 			"kubernetes_storage_class":             resourceKubernetesStorageClass(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
+
+	for name, r := range p.ResourcesMap {
+		if createsNamespacedObject(name, r) {
+			r.Create = ensureNamespaceBeforeCreate(r.Create)
+		}
+	}
+
+	return p
+}
+
+// kubeProvider is the meta handed to resources and data sources. It embeds the
+// clientset along with the provider-wide settings that affect planning.
+type kubeProvider struct {
+	*kubernetes.Clientset
+
+	validateEnvVarReferences       bool
+	validateTerminationGracePeriod bool
+	validateReadOnlyRootFilesystem bool
+	validateStatefulSetService     bool
+	checkNamespaceExists           bool
+	createMissingNamespaces        bool
+}
+
+// ensureNamespace checks that the namespace exists, and creates it if it
+// doesn't and the provider is configured to.
+func (p *kubeProvider) ensureNamespace(name string) error {
+	if !p.checkNamespaceExists && !p.createMissingNamespaces {
+		return nil
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	_, err := p.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to check namespace %q: %s", name, err)
+	}
+	if !p.createMissingNamespaces {
+		return fmt.Errorf("Namespace %q does not exist. Create it first, or set `create_missing_namespaces` on the provider", name)
+	}
+
+	log.Printf("[INFO] Creating missing namespace %q", name)
+	_, err = p.CoreV1().Namespaces().Create(&api.Namespace{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name},
+	})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("Failed to create namespace %q: %s", name, err)
+	}
+	return nil
+}
+
+func isNamespacedResource(r *schema.Resource) bool {
+	metadata, ok := r.Schema["metadata"]
+	if !ok {
+		return false
+	}
+	elem, ok := metadata.Elem.(*schema.Resource)
+	if !ok {
+		return false
+	}
+	_, ok = elem.Schema["namespace"]
+	return ok
+}
+
+// patchingResources patch objects that must already exist, so their namespace
+// is never created for them.
+var patchingResources = map[string]bool{
+	"kubernetes_default_image_pull_secret": true,
+	"kubernetes_service_status":            true,
+}
+
+// createsNamespacedObject tells whether creating the resource creates an object
+// in a namespace, which may have to be checked or created first.
+func createsNamespacedObject(name string, r *schema.Resource) bool {
+	return isNamespacedResource(r) && !patchingResources[name]
+}
+
+func ensureNamespaceBeforeCreate(create schema.CreateFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		err := meta.(*kubeProvider).ensureNamespace(d.Get("metadata.0.namespace").(string))
+		if err != nil {
+			return err
+		}
+		return create(d, meta)
+	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -175,13 +341,159 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	if v, ok := d.GetOk("token"); ok {
 		cfg.BearerToken = v.(string)
 	}
+	if v, ok := d.GetOk("token_file"); ok {
+		err = configureTokenFile(cfg, v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure token file: %s", err)
+		}
+	}
+	if v, ok := d.GetOk("proxy_url"); ok {
+		err = configureProxy(cfg, v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure proxy: %s", err)
+		}
+	}
 
 	k, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to configure: %s", err)
 	}
 
-	return k, nil
+	if d.Get("ping_on_configure").(bool) {
+		log.Printf("[DEBUG] Checking connectivity to the Kubernetes API server at %q", cfg.Host)
+		v, err := k.Discovery().ServerVersion()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to reach the Kubernetes API server at %q (check host and credentials): %s", cfg.Host, err)
+		}
+		log.Printf("[INFO] Connected to Kubernetes API server version %s", v.String())
+	}
+
+	return &kubeProvider{
+		Clientset:                      k,
+		validateEnvVarReferences:       d.Get("validate_env_var_references").(bool),
+		validateTerminationGracePeriod: d.Get("validate_termination_grace_period").(bool),
+		validateReadOnlyRootFilesystem: d.Get("validate_read_only_root_filesystem").(bool),
+		validateStatefulSetService:     d.Get("validate_stateful_set_service").(bool),
+		checkNamespaceExists:           d.Get("check_namespace_exists").(bool),
+		createMissingNamespaces:        d.Get("create_missing_namespaces").(bool),
+	}, nil
+}
+
+// configureProxy routes the API requests through the given proxy. The proxy is
+// set on a dedicated transport rather than through the HTTP_PROXY environment
+// variables, which would also apply to every other provider.
+func configureProxy(cfg *restclient.Config, proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := restclient.TLSConfigFor(cfg)
+	if err != nil {
+		return err
+	}
+
+	cfg.Transport = utilnet.SetTransportDefaults(&http.Transport{
+		Proxy:           http.ProxyURL(u),
+		TLSClientConfig: tlsConfig,
+	})
+	// The TLS settings are now part of the transport and client-go refuses to
+	// build a client which has both.
+	cfg.TLSClientConfig = restclient.TLSClientConfig{}
+
+	log.Printf("[DEBUG] Using proxy %s to reach the Kubernetes API server", u.Host)
+	return nil
+}
+
+// configureTokenFile authenticates the API requests with the bearer token held
+// in the given file. The file is read once here so that a missing or empty file
+// fails the configuration, then again on requests whenever it has been
+// modified, so that rotated tokens are picked up during long runs.
+func configureTokenFile(cfg *restclient.Config, path string) error {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return err
+	}
+	tf := &tokenFile{path: path}
+	if _, err := tf.Token(); err != nil {
+		return err
+	}
+
+	// The token of the file takes precedence over one from the config file
+	cfg.BearerToken = ""
+	wrap := cfg.WrapTransport
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &tokenFileRoundTripper{tokenFile: tf, rt: rt}
+	}
+
+	log.Printf("[DEBUG] Using bearer token from %s", path)
+	return nil
+}
+
+// tokenFile caches the content of a token file until its modification time
+// changes.
+type tokenFile struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+// Token returns the current token of the file. If the file can't be read after
+// a token was loaded, the last token is kept and the error is only logged, the
+// API server then decides whether it is still valid.
+func (f *tokenFile) Token() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fi, err := os.Stat(f.path)
+	if err == nil && f.token != "" && fi.ModTime().Equal(f.modTime) {
+		return f.token, nil
+	}
+	var token string
+	if err == nil {
+		var b []byte
+		b, err = ioutil.ReadFile(f.path)
+		token = strings.TrimSpace(string(b))
+		if err == nil && token == "" {
+			err = fmt.Errorf("token file %q is empty", f.path)
+		}
+	}
+	if err != nil {
+		if f.token == "" {
+			return "", err
+		}
+		log.Printf("[WARN] Failed to reload token file, using the previous token: %s", err)
+		return f.token, nil
+	}
+
+	f.token = token
+	f.modTime = fi.ModTime()
+	return f.token, nil
+}
+
+type tokenFileRoundTripper struct {
+	tokenFile *tokenFile
+	rt        http.RoundTripper
+}
+
+func (rt *tokenFileRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := rt.tokenFile.Token()
+	if err != nil {
+		return nil, err
+	}
+	// Round trippers must not modify the original request
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	return rt.rt.RoundTrip(r)
 }
 
 func tryLoadingConfigFile(d *schema.ResourceData) (*restclient.Config, error) {
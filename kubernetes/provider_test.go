@@ -2,9 +2,13 @@ package kubernetes
 
 import (
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -14,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
+	restclient "k8s.io/client-go/rest"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -38,6 +43,41 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_namespacedResources(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	cases := map[string]bool{
+		"kubernetes_cluster_role":          false,
+		"kubernetes_config_map":            true,
+		"kubernetes_deployment":            true,
+		"kubernetes_service":               true,
+		"kubernetes_namespace":             false,
+		"kubernetes_persistent_volume":     false,
+		"kubernetes_pod_disruption_budget": true,
+		"kubernetes_storage_class":         false,
+	}
+	for name, namespaced := range cases {
+		if isNamespacedResource(p.ResourcesMap[name]) != namespaced {
+			t.Errorf("Expected %s to be namespaced: %t", name, namespaced)
+		}
+	}
+}
+
+func TestProvider_createsNamespacedObject(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	cases := map[string]bool{
+		"kubernetes_config_map":                true,
+		"kubernetes_deployment":                true,
+		"kubernetes_namespace":                 false,
+		"kubernetes_default_image_pull_secret": false,
+		"kubernetes_service_status":            false,
+	}
+	for name, creates := range cases {
+		if createsNamespacedObject(name, p.ResourcesMap[name]) != creates {
+			t.Errorf("Expected %s to create a namespaced object: %t", name, creates)
+		}
+	}
+}
+
 func TestProvider_configure(t *testing.T) {
 	resetEnv := unsetEnv(t)
 	defer resetEnv()
@@ -57,6 +97,169 @@ func TestProvider_configure(t *testing.T) {
 	}
 }
 
+func TestProvider_configureContextOverrides(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	cases := []struct {
+		Name         string
+		Config       map[string]interface{}
+		ExpectedHost string
+	}{
+		{
+			"context",
+			map[string]interface{}{"config_context": "gcp"},
+			"https://127.0.0.1",
+		},
+		{
+			"other context",
+			map[string]interface{}{"config_context": "staging"},
+			"https://127.0.0.2",
+		},
+		{
+			"cluster override",
+			map[string]interface{}{"config_context": "gcp", "config_context_cluster": "staging"},
+			"https://127.0.0.2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Config["config_path"] = "test-fixtures/kube-config.yaml"
+			d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, tc.Config)
+			cfg, err := tryLoadingConfigFile(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Host != tc.ExpectedHost {
+				t.Fatalf("Expected host %q, given %q", tc.ExpectedHost, cfg.Host)
+			}
+		})
+	}
+}
+
+func TestProvider_configurePingUnreachable(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"host":              "http://127.0.0.1:1",
+		"load_config_file":  false,
+		"ping_on_configure": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc := terraform.NewResourceConfig(c)
+	p := Provider()
+	err = p.Configure(rc)
+	if err == nil {
+		t.Fatal("Expected configuration to fail when the API server is unreachable")
+	}
+	if !strings.Contains(err.Error(), "Failed to reach the Kubernetes API server") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestProvider_configureProxy(t *testing.T) {
+	cfg := &restclient.Config{
+		Host: "https://10.0.0.1",
+		TLSClientConfig: restclient.TLSClientConfig{
+			Insecure: true,
+		},
+	}
+	err := configureProxy(cfg, "http://proxy.example.com:3128")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr, ok := cfg.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, given %T", cfg.Transport)
+	}
+	req, _ := http.NewRequest("GET", cfg.Host+"/api", nil)
+	proxy, err := tr.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxy == nil || proxy.String() != "http://proxy.example.com:3128" {
+		t.Fatalf("Unexpected proxy: %#v", proxy)
+	}
+	if tr.TLSClientConfig == nil || !tr.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("Expected the TLS settings to be carried over to the transport")
+	}
+
+	// client-go rejects custom transports combined with TLS options
+	if _, err := kubernetes.NewForConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProvider_configureTokenFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+
+	var given string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"major":"1","minor":"7"}`))
+	}))
+	defer server.Close()
+
+	cfg := &restclient.Config{Host: server.URL, BearerToken: "from-config"}
+	if err := configureTokenFile(cfg, f.Name()); err == nil {
+		t.Fatal("Expected an empty token file to fail the configuration")
+	}
+
+	if err := ioutil.WriteFile(f.Name(), []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := configureTokenFile(cfg, f.Name()); err != nil {
+		t.Fatal(err)
+	}
+	k, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := k.Discovery().ServerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Bearer first"; given != expected {
+		t.Fatalf("Unexpected Authorization header.\nExpected: %q\nGiven:    %q", expected, given)
+	}
+
+	// Rotate the token, the modification time may otherwise not change
+	// within the resolution of the file system.
+	if err := ioutil.WriteFile(f.Name(), []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(f.Name(), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Discovery().ServerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Bearer second"; given != expected {
+		t.Fatalf("Unexpected Authorization header.\nExpected: %q\nGiven:    %q", expected, given)
+	}
+
+	// A token file which disappears keeps the last token
+	os.Remove(f.Name())
+	if _, err := k.Discovery().ServerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Bearer second"; given != expected {
+		t.Fatalf("Unexpected Authorization header.\nExpected: %q\nGiven:    %q", expected, given)
+	}
+}
+
 func unsetEnv(t *testing.T) func() {
 	e := getEnv()
 
@@ -247,7 +450,7 @@ func getFirstNode() (api.Node, error) {
 	if meta == nil {
 		return api.Node{}, errors.New("Provider not initialized, unable to get cluster node")
 	}
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 	resp, err := conn.CoreV1().Nodes().List(metav1.ListOptions{})
 	if err != nil {
 		return api.Node{}, err
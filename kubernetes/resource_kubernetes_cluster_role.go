@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	rbacv1beta1 "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+func resourceKubernetesClusterRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesClusterRoleCreate,
+		Read:   resourceKubernetesClusterRoleRead,
+		Exists: resourceKubernetesClusterRoleExists,
+		Update: resourceKubernetesClusterRoleUpdate,
+		Delete: resourceKubernetesClusterRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("cluster role", true),
+			"policy_rule": {
+				Type:        schema.TypeList,
+				Description: "List of PolicyRules for this ClusterRole",
+				Required:    true,
+				MinItems:    1,
+				Elem:        policyRuleSchema(),
+			},
+		},
+	}
+}
+
+func resourceKubernetesClusterRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	role := rbacv1beta1.ClusterRole{
+		ObjectMeta: metadata,
+		Rules:      expandPolicyRules(d.Get("policy_rule").([]interface{})),
+	}
+	log.Printf("[INFO] Creating new cluster role: %#v", role)
+	out, err := conn.RbacV1beta1().ClusterRoles().Create(&role)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted new cluster role: %#v", out)
+	d.SetId(out.Name)
+
+	return resourceKubernetesClusterRoleRead(d, meta)
+}
+
+func resourceKubernetesClusterRoleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	name := d.Id()
+	log.Printf("[INFO] Reading cluster role %s", name)
+	role, err := conn.RbacV1beta1().ClusterRoles().Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received cluster role: %#v", role)
+	err = d.Set("metadata", flattenMetadata(role.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+	err = d.Set("policy_rule", flattenPolicyRules(role.Rules))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesClusterRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	name := d.Id()
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("policy_rule") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/rules",
+			Value: expandPolicyRules(d.Get("policy_rule").([]interface{})),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating cluster role %q: %v", name, string(data))
+	out, err := conn.RbacV1beta1().ClusterRoles().Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update cluster role: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated cluster role: %#v", out)
+	d.SetId(out.Name)
+
+	return resourceKubernetesClusterRoleRead(d, meta)
+}
+
+func resourceKubernetesClusterRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	name := d.Id()
+	log.Printf("[INFO] Deleting cluster role: %#v", name)
+	err := conn.RbacV1beta1().ClusterRoles().Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	log.Printf("[INFO] Cluster role %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesClusterRoleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubeProvider).Clientset
+
+	name := d.Id()
+	log.Printf("[INFO] Checking cluster role %s", name)
+	_, err := conn.RbacV1beta1().ClusterRoles().Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rbacv1beta1 "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+func TestAccKubernetesClusterRole_basic(t *testing.T) {
+	var conf rbacv1beta1.ClusterRole
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_cluster_role.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesClusterRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesClusterRoleConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesClusterRoleExists("kubernetes_cluster_role.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "id", name),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "metadata.0.labels.TestLabelOne", "one"),
+					resource.TestCheckResourceAttrSet("kubernetes_cluster_role.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_cluster_role.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "policy_rule.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "policy_rule.0.resources.0", "nodes"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "policy_rule.1.non_resource_urls.0", "/healthz"),
+					testAccCheckClusterRoleRules(&conf, []rbacv1beta1.PolicyRule{
+						{
+							APIGroups: []string{""},
+							Resources: []string{"nodes"},
+							Verbs:     []string{"get", "list", "watch"},
+						},
+						{
+							NonResourceURLs: []string{"/healthz"},
+							Verbs:           []string{"get"},
+						},
+					}),
+				),
+			},
+			{
+				Config: testAccKubernetesClusterRoleConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesClusterRoleExists("kubernetes_cluster_role.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "policy_rule.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "policy_rule.0.resources.0", "namespaces"),
+					testAccCheckClusterRoleRules(&conf, []rbacv1beta1.PolicyRule{
+						{
+							APIGroups: []string{""},
+							Resources: []string{"namespaces"},
+							Verbs:     []string{"get"},
+						},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesClusterRole_importBasic(t *testing.T) {
+	resourceName := "kubernetes_cluster_role.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesClusterRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesClusterRoleConfig_basic(name),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckClusterRoleRules(role *rbacv1beta1.ClusterRole, expected []rbacv1beta1.PolicyRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !reflect.DeepEqual(role.Rules, expected) {
+			return fmt.Errorf("Cluster role rules don't match.\nExpected: %#v\nGiven: %#v", expected, role.Rules)
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesClusterRoleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_cluster_role" {
+			continue
+		}
+		_, err := conn.RbacV1beta1().ClusterRoles().Get(rs.Primary.ID, meta_v1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Cluster role still exists: %s", rs.Primary.ID)
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesClusterRoleExists(n string, obj *rbacv1beta1.ClusterRole) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		out, err := conn.RbacV1beta1().ClusterRoles().Get(rs.Primary.ID, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesClusterRoleConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cluster_role" "test" {
+	metadata {
+		labels {
+			TestLabelOne = "one"
+		}
+		name = "%s"
+	}
+
+	policy_rule {
+		api_groups = [""]
+		resources  = ["nodes"]
+		verbs      = ["get", "list", "watch"]
+	}
+
+	policy_rule {
+		non_resource_urls = ["/healthz"]
+		verbs             = ["get"]
+	}
+}`, name)
+}
+
+func testAccKubernetesClusterRoleConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cluster_role" "test" {
+	metadata {
+		labels {
+			TestLabelOne = "one"
+		}
+		name = "%s"
+	}
+
+	policy_rule {
+		api_groups = [""]
+		resources  = ["namespaces"]
+		verbs      = ["get"]
+	}
+}`, name)
+}
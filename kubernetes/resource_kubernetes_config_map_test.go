@@ -10,7 +10,6 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -189,6 +188,89 @@ func TestAccKubernetesConfigMap_importGeneratedName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesConfigMap_missingNamespace(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	nsName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesConfigMapConfig_missingNamespace(name, nsName, false),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("Namespace %q does not exist", nsName)),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesConfigMap_createMissingNamespace(t *testing.T) {
+	var conf api.ConfigMap
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	nsName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			// The namespace isn't managed by Terraform, so it has to be cleaned up here
+			conn := testAccProvider.Meta().(*kubeProvider).Clientset
+			conn.CoreV1().Namespaces().Delete(nsName, &meta_v1.DeleteOptions{})
+			return testAccCheckKubernetesConfigMapDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapConfig_missingNamespace(name, nsName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "metadata.0.namespace", nsName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesConfigMap_deleteTwice(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapDeletedTwice("kubernetes_config_map.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckKubernetesConfigMapDeletedTwice deletes the config map as if
+// two destroys raced each other. The second one must succeed as the config map
+// is gone either way.
+func testAccCheckKubernetesConfigMapDeletedTwice(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		r := resourceKubernetesConfigMap()
+		for i := 0; i < 2; i++ {
+			d := r.Data(&terraform.InstanceState{ID: rs.Primary.ID})
+			if err := r.Delete(d, testAccProvider.Meta()); err != nil {
+				return fmt.Errorf("Delete #%d of config map %s failed: %s", i+1, rs.Primary.ID, err)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckConfigMapData(m *api.ConfigMap, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Data) == 0 {
@@ -203,7 +285,7 @@ func testAccCheckConfigMapData(m *api.ConfigMap, expected map[string]string) res
 }
 
 func testAccCheckKubernetesConfigMapDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_config_map" {
@@ -231,7 +313,7 @@ func testAccCheckKubernetesConfigMapExists(n string, obj *api.ConfigMap) resourc
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -330,3 +412,22 @@ resource "kubernetes_config_map" "test" {
 	}
 }`, prefix)
 }
+
+func testAccKubernetesConfigMapConfig_missingNamespace(name, nsName string, create bool) string {
+	return fmt.Sprintf(`
+provider "kubernetes" {
+	check_namespace_exists    = true
+	create_missing_namespaces = %t
+}
+
+resource "kubernetes_config_map" "test" {
+	metadata {
+		name      = "%s"
+		namespace = "%s"
+	}
+	data {
+		one = "first"
+	}
+}
+`, create, name, nsName)
+}
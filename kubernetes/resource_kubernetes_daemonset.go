@@ -10,7 +10,6 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
 
@@ -24,7 +23,8 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		SchemaVersion: 1,
+		CustomizeDiff: podSpecCustomizeDiff("spec.0.template.0.spec.0."),
+		SchemaVersion: 2,
 		MigrateState:  resourceKubernetesDaemonSetStateUpgrader,
 
 		Timeouts: &schema.ResourceTimeout{
@@ -49,9 +49,13 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 							Default:     0,
 						},
 						"selector": {
-							Type:        schema.TypeMap,
-							Description: "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Type:        schema.TypeList,
+							Description: "A label query over pods that are managed by the daemon set. Must match in order to be controlled. It must match the pod template's labels. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
 							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(true),
+							},
 						},
 						"strategy": {
 							Type:        schema.TypeList,
@@ -76,16 +80,18 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"max_surge": {
-													Type:        schema.TypeString,
-													Description: "max surge",
-													Optional:    true,
-													Default:     1,
+													Type:         schema.TypeString,
+													Description:  "The maximum number of pods that can be scheduled above the desired number of pods, either an absolute number, e.g. `2`, or a percentage of the desired pods, e.g. `25%`.",
+													Optional:     true,
+													Default:      "1",
+													ValidateFunc: validateIntOrPercent,
 												},
 												"max_unavailable": {
-													Type:        schema.TypeString,
-													Description: "max unavailable",
-													Optional:    true,
-													Default:     1,
+													Type:         schema.TypeString,
+													Description:  "The maximum number of pods that can be unavailable during the update, either an absolute number, e.g. `1`, or a percentage of the desired pods, e.g. `25%`.",
+													Optional:     true,
+													Default:      "1",
+													ValidateFunc: validateIntOrPercent,
 												},
 											},
 										},
@@ -156,7 +162,7 @@ func buildDaemonSetObject(d *schema.ResourceData) (*v1beta1.DaemonSet, error) {
 }
 
 func resourceKubernetesDaemonSetCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	daemonset, err := buildDaemonSetObject(d)
 	if err != nil {
@@ -177,7 +183,7 @@ func resourceKubernetesDaemonSetCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesDaemonSetRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Reading daemonset %s", name)
@@ -213,7 +219,7 @@ func resourceKubernetesDaemonSetRead(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesDaemonSetUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 	namespace, name, err := idParts(d.Id())
 
 	daemonset, err := buildDaemonSetObject(d)
@@ -232,7 +238,7 @@ func resourceKubernetesDaemonSetUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesDaemonSetDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -241,7 +247,10 @@ func resourceKubernetesDaemonSetDelete(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Deleting daemonset: %#v", name)
 
 	falseVar := false
-	conn.DaemonSets(namespace).Delete(name, &metav1.DeleteOptions{OrphanDependents: &falseVar})
+	err = conn.DaemonSets(namespace).Delete(name, &metav1.DeleteOptions{OrphanDependents: &falseVar})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
 
 	log.Printf("[INFO] DaemonSet %s deleted", name)
 
@@ -250,7 +259,7 @@ func resourceKubernetesDaemonSetDelete(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesDaemonSetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Checking daemonset %s", name)
@@ -280,6 +289,13 @@ func resourceKubernetesDaemonSetStateUpgrader(
 		if err != nil {
 			return is, err
 		}
+		fallthrough
+	case 1:
+		log.Println("[INFO] Found Kubernetes DaemonSet State v1; migrating to v2")
+		is, err = migrateStateSelectorMapToBlock(is)
+		if err != nil {
+			return is, err
+		}
 
 	default:
 		return is, fmt.Errorf("Unexpected schema version: %d", v)
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
 
@@ -197,7 +196,7 @@ func TestAccKubernetesDaemonSet_noTopLevelLabels(t *testing.T) {
 }
 
 func testAccCheckKubernetesDaemonSetDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_daemonset" {
@@ -227,7 +226,7 @@ func testAccCheckKubernetesDaemonSetExists(n string, obj *v1beta1.DaemonSet) res
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
@@ -252,7 +251,9 @@ resource "kubernetes_daemonset" "test" {
   }
   spec {
     selector {
-      foo = "bar"
+      match_labels {
+        foo = "bar"
+      }
     }
     template {
 			metadata {
@@ -289,9 +290,11 @@ resource "kubernetes_daemonset" "test" {
   }
   spec {
     selector {
-      TestLabelOne = "one"
-      TestLabelTwo = "two"
-      TestLabelThree = "three"
+      match_labels {
+        TestLabelOne = "one"
+        TestLabelTwo = "two"
+        TestLabelThree = "three"
+      }
     }
     template {
 			metadata {
@@ -329,9 +332,11 @@ resource "kubernetes_daemonset" "test" {
   }
   spec {
     selector {
-      TestLabelOne = "one"
-      TestLabelTwo = "two"
-      TestLabelThree = "three"
+      match_labels {
+        TestLabelOne = "one"
+        TestLabelTwo = "two"
+        TestLabelThree = "three"
+      }
     }
     template {
 			metadata {
@@ -364,8 +369,10 @@ resource "kubernetes_daemonset" "test" {
 
   spec {
     selector {
-      Test = "TfAcceptanceTest"
-		}
+      match_labels {
+        Test = "TfAcceptanceTest"
+      }
+    }
     template {
 			metadata {
 				labels {
@@ -402,8 +409,10 @@ resource "kubernetes_daemonset" "test" {
 
   spec {
     selector {
-      Test = "TfAcceptanceTest"
-		}
+      match_labels {
+        Test = "TfAcceptanceTest"
+      }
+    }
     template {
 			metadata {
 				labels {
@@ -440,8 +449,10 @@ resource "kubernetes_daemonset" "test" {
 
   spec {
     selector {
-			foo = "bar"
-		}
+      match_labels {
+        foo = "bar"
+      }
+    }
     template {
 			metadata {
 				labels {
@@ -474,8 +485,10 @@ resource "kubernetes_daemonset" "test" {
 
   spec {
     selector {
-			foo = "bar"
-		}
+      match_labels {
+        foo = "bar"
+      }
+    }
     template {
 			metadata {
 				labels {
@@ -0,0 +1,210 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func resourceKubernetesDefaultImagePullSecret() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesDefaultImagePullSecretCreate,
+		Read:   resourceKubernetesDefaultImagePullSecretRead,
+		Delete: resourceKubernetesDefaultImagePullSecretDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Identifies the existing service account whose image pull secrets are extended.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the service account.",
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "default",
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the service account.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"secret_name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the image pull secret to add to the service account. The secret must be in the same namespace.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateName,
+			},
+		},
+	}
+}
+
+func resourceKubernetesDefaultImagePullSecretCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace := d.Get("metadata.0.namespace").(string)
+	name := d.Get("metadata.0.name").(string)
+	secret := d.Get("secret_name").(string)
+
+	// The default service account is created asynchronously along with its
+	// namespace. Other entries may be added concurrently, so conflicts are retried.
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return resource.RetryableError(fmt.Errorf("Waiting for service account %s/%s to be created", namespace, name))
+			}
+			return resource.NonRetryableError(err)
+		}
+		// Adopting the entry would remove it on destroy, although it wasn't added here
+		if imagePullSecretIndex(svcAcc.ImagePullSecrets, secret) != -1 {
+			return resource.NonRetryableError(fmt.Errorf("Service account %s/%s already uses image pull secret %s, "+
+				"import it to manage the entry: terraform import <address> %s/%s/%s", namespace, name, secret, namespace, name, secret))
+		}
+
+		svcAcc.ImagePullSecrets = append(svcAcc.ImagePullSecrets, v1.LocalObjectReference{Name: secret})
+		log.Printf("[INFO] Adding image pull secret %s to service account %s/%s", secret, namespace, name)
+		_, err = conn.CoreV1().ServiceAccounts(namespace).Update(svcAcc)
+		if err != nil {
+			if errors.IsConflict(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(fmt.Errorf("Failed to add image pull secret: %s", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", namespace, name, secret))
+
+	return resourceKubernetesDefaultImagePullSecretRead(d, meta)
+}
+
+func resourceKubernetesDefaultImagePullSecretRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, secret, err := defaultImagePullSecretIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading image pull secrets of service account %s/%s", namespace, name)
+	svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[WARN] Service account %s/%s not found, removing image pull secret %s from state", namespace, name, secret)
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	if imagePullSecretIndex(svcAcc.ImagePullSecrets, secret) == -1 {
+		log.Printf("[WARN] Image pull secret %s was removed from service account %s/%s", secret, namespace, name)
+		d.SetId("")
+		return nil
+	}
+
+	err = d.Set("metadata", []interface{}{map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+	}})
+	if err != nil {
+		return err
+	}
+	err = d.Set("secret_name", secret)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesDefaultImagePullSecretDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, secret, err := defaultImagePullSecretIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.Printf("[INFO] Service account %s/%s no longer exists, nothing to remove", namespace, name)
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+		i := imagePullSecretIndex(svcAcc.ImagePullSecrets, secret)
+		if i == -1 {
+			log.Printf("[INFO] Image pull secret %s was already removed from service account %s/%s", secret, namespace, name)
+			return nil
+		}
+
+		// Other entries are left untouched, whoever manages them
+		svcAcc.ImagePullSecrets = append(svcAcc.ImagePullSecrets[:i], svcAcc.ImagePullSecrets[i+1:]...)
+		log.Printf("[INFO] Removing image pull secret %s from service account %s/%s", secret, namespace, name)
+		_, err = conn.CoreV1().ServiceAccounts(namespace).Update(svcAcc)
+		if err != nil {
+			if errors.IsConflict(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(fmt.Errorf("Failed to remove image pull secret: %s", err))
+		}
+		log.Printf("[INFO] Image pull secret %s removed from service account %s/%s", secret, namespace, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imagePullSecretIndex(secrets []v1.LocalObjectReference, name string) int {
+	for i, s := range secrets {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func defaultImagePullSecretIdParts(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("Unexpected ID format (%q), expected %q.", id, "namespace/service_account/secret")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,193 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func TestAccKubernetesDefaultImagePullSecret_basic(t *testing.T) {
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDefaultImagePullSecretDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDefaultImagePullSecretConfig_basic(namespace, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_default_image_pull_secret.registry", "metadata.0.name", "default"),
+					resource.TestCheckResourceAttr("kubernetes_default_image_pull_secret.registry", "metadata.0.namespace", namespace),
+					resource.TestCheckResourceAttr("kubernetes_default_image_pull_secret.registry", "secret_name", "registry"),
+					testAccCheckServiceAccountImagePullSecretNames(namespace, "default", []string{"registry", "mirror"}),
+				),
+			},
+			// Only the entry of the destroyed resource is removed
+			{
+				Config: testAccKubernetesDefaultImagePullSecretConfig_basic(namespace, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckServiceAccountImagePullSecretNames(namespace, "default", []string{"registry"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDefaultImagePullSecret_importBasic(t *testing.T) {
+	resourceName := "kubernetes_default_image_pull_secret.registry"
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDefaultImagePullSecretDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDefaultImagePullSecretConfig_basic(namespace, false),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDefaultImagePullSecret_alreadyPresent(t *testing.T) {
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDefaultImagePullSecretDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDefaultImagePullSecretConfig_alreadyPresent(namespace),
+				ExpectError: regexp.MustCompile("already uses image pull secret registry, import it"),
+			},
+		},
+	})
+}
+
+func TestImagePullSecretIndex(t *testing.T) {
+	secrets := []api.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}}
+
+	cases := []struct {
+		Name     string
+		Expected int
+	}{
+		{"registry", 0},
+		{"mirror", 1},
+		{"other", -1},
+	}
+	for _, tc := range cases {
+		if i := imagePullSecretIndex(secrets, tc.Name); i != tc.Expected {
+			t.Fatalf("Expected index of %q to be %d, got %d", tc.Name, tc.Expected, i)
+		}
+	}
+}
+
+func testAccCheckServiceAccountImagePullSecretNames(namespace, name string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if len(svcAcc.ImagePullSecrets) != len(expected) {
+			return fmt.Errorf("Expected image pull secrets %q, got %#v", expected, svcAcc.ImagePullSecrets)
+		}
+		for _, secret := range expected {
+			if imagePullSecretIndex(svcAcc.ImagePullSecrets, secret) == -1 {
+				return fmt.Errorf("Expected image pull secrets %q, got %#v", expected, svcAcc.ImagePullSecrets)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesDefaultImagePullSecretDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_default_image_pull_secret" {
+			continue
+		}
+
+		namespace, name, secret, err := defaultImagePullSecretIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil && imagePullSecretIndex(resp.ImagePullSecrets, secret) != -1 {
+			return fmt.Errorf("Service account %s/%s still uses image pull secret %s", namespace, name, secret)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesDefaultImagePullSecretConfig_basic(namespace string, withMirror bool) string {
+	mirror := ""
+	if withMirror {
+		mirror = `
+resource "kubernetes_default_image_pull_secret" "mirror" {
+	metadata {
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+	secret_name = "mirror"
+}`
+	}
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_default_image_pull_secret" "registry" {
+	metadata {
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+	secret_name = "registry"
+}
+%s
+`, namespace, mirror)
+}
+
+func testAccKubernetesDefaultImagePullSecretConfig_alreadyPresent(namespace string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_service_account" "test" {
+	metadata {
+		name      = "builder"
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+	image_pull_secret {
+		name = "registry"
+	}
+}
+
+resource "kubernetes_default_image_pull_secret" "registry" {
+	metadata {
+		name      = "${kubernetes_service_account.test.metadata.0.name}"
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+	secret_name = "registry"
+}
+`, namespace)
+}
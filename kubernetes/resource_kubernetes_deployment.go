@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 	"time"
 
@@ -11,13 +12,15 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 	kubernetes "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
 
 func resourceKubernetesDeployment() *schema.Resource {
-	return &schema.Resource{
+	s := &schema.Resource{
 		Create: resourceKubernetesDeploymentCreate,
 		Read:   resourceKubernetesDeploymentRead,
 		Exists: resourceKubernetesDeploymentExists,
@@ -26,8 +29,9 @@ func resourceKubernetesDeployment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		SchemaVersion: 2,
+		SchemaVersion: 3,
 		MigrateState:  resourceKubernetesDeploymentStateUpgrader,
+		CustomizeDiff: resourceKubernetesDeploymentCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -42,6 +46,151 @@ func resourceKubernetesDeployment() *schema.Resource {
 				Optional: true,
 				Removed:  "To better match the Kubernetes API, the name attribute should be configured under the metadata block. Please update your Terraform configuration.",
 			},
+			"active_replica_set": {
+				Type:        schema.TypeString,
+				Description: "Name of the replica set of the current revision, i.e. the one whose `pod-template-hash` label the new pods get. Empty until the controller created it.",
+				Computed:    true,
+			},
+			"config_checksum_annotations": {
+				Type:         schema.TypeMap,
+				Description:  "Annotations of the pod template to set to the checksum of a ConfigMap or Secret, so that changing it rolls the deployment. Keys are annotation names, values reference the object as `Kind/name` or `Kind/namespace/name`.",
+				Optional:     true,
+				ValidateFunc: validateConfigObjectReferenceMap,
+			},
+			"delete_propagation": {
+				Type:         schema.TypeString,
+				Description:  "How the replica sets and pods of the deployment are deleted with it. One of Foreground, Background or Orphan. Defaults to Foreground. Orphan keeps them running and skips scaling the deployment down before deleting it.",
+				Optional:     true,
+				ValidateFunc: validateAttributeValueIsIn([]string{"Foreground", "Background", "Orphan"}),
+			},
+			"orphan_cleanup_delay_seconds": {
+				Type:         schema.TypeInt,
+				Description:  "Only applies to `delete_propagation = \"Orphan\"`. Deletes the orphaned replica sets, and so their pods, this many seconds after the deployment was deleted, e.g. to hand traffic over to a replacement first. Must be shorter than the delete timeout. Orphans are kept when unset.",
+				Optional:     true,
+				ValidateFunc: validatePositiveInteger,
+			},
+			"effective_image_pull_secrets": {
+				Type:        schema.TypeList,
+				Description: "Names of the image pull secrets available to the pods, i.e. the ones of the pod template merged with the ones of its service account. Helps to debug failing image pulls.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ignore_container_images": {
+				Type:        schema.TypeSet,
+				Description: "Names of containers whose image is managed outside of Terraform, e.g. by a CD tool. Their image is only set on creation and changes to it are ignored.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"restarted_at": {
+				Type:        schema.TypeString,
+				Description: "Changing this value, e.g. to the current timestamp, triggers a rolling restart of the pods like `kubectl rollout restart`. It's set as the `kubectl.kubernetes.io/restartedAt` annotation of the pod template.",
+				Optional:    true,
+			},
+			"skip_drain_on_delete": {
+				Type:        schema.TypeBool,
+				Description: "Deletes the deployment right away with the configured `delete_propagation`, instead of scaling it down to zero replicas and waiting for its pods to be gone first. Speeds up tearing down e.g. test environments. When draining, the wait for the pods is extended beyond the delete timeout to their termination grace period plus 30s if that's longer.",
+				Optional:    true,
+				Default:     false,
+			},
+			"wait_for": {
+				Type:        schema.TypeList,
+				Description: "Conditions of the deployment to wait for on create and update instead of its rollout, e.g. `Available` being `True`. All of them must be met.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"condition": {
+							Type:         schema.TypeString,
+							Description:  "Type of the deployment condition, one of Available, Progressing or ReplicaFailure.",
+							Required:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Available", "Progressing", "ReplicaFailure"}),
+						},
+						"status": {
+							Type:         schema.TypeString,
+							Description:  "Status of the condition to wait for, one of True, False or Unknown. Defaults to True.",
+							Optional:     true,
+							Default:      "True",
+							ValidateFunc: validateAttributeValueIsIn([]string{"True", "False", "Unknown"}),
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "Most recently observed status of the deployment. Read-only.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"available_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Total number of available pods (ready for at least min_ready_seconds) targeted by this deployment.",
+							Computed:    true,
+						},
+						"ready_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Total number of ready pods targeted by this deployment.",
+							Computed:    true,
+						},
+						"updated_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Total number of non-terminated pods targeted by this deployment that have the desired template spec.",
+							Computed:    true,
+						},
+						"unavailable_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Total number of unavailable pods targeted by this deployment.",
+							Computed:    true,
+						},
+						"observed_generation": {
+							Type:        schema.TypeInt,
+							Description: "The generation observed by the deployment controller.",
+							Computed:    true,
+						},
+						"condition": {
+							Type:        schema.TypeList,
+							Description: "Latest available observations of the deployment's current state.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Description: "Type of the condition, e.g. Available or Progressing.",
+										Computed:    true,
+									},
+									"status": {
+										Type:        schema.TypeString,
+										Description: "Status of the condition, one of True, False or Unknown.",
+										Computed:    true,
+									},
+									"reason": {
+										Type:        schema.TypeString,
+										Description: "The reason for the condition's last transition.",
+										Computed:    true,
+									},
+									"message": {
+										Type:        schema.TypeString,
+										Description: "A human readable message indicating details about the transition.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"template_annotations_from": {
+				Type:        schema.TypeList,
+				Description: "ConfigMaps and Secrets whose content is hashed into annotations of the pod template, so that changing them rolls the deployment.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: configObjectReferenceFields(),
+				},
+			},
+			"template_checksums": {
+				Type:        schema.TypeMap,
+				Description: "Checksums of the objects referenced by `template_annotations_from` and `config_checksum_annotations`, by annotation name, as injected into the pod template.",
+				Computed:    true,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the deployment. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -75,15 +224,37 @@ func resourceKubernetesDeployment() *schema.Resource {
 						},
 						"revision_history_limit": {
 							Type:        schema.TypeInt,
-							Description: "The number of old ReplicaSets to retain to allow rollback. Defaults to 10.",
+							Description: "The number of old ReplicaSets to retain to allow rollback. 0 keeps none, which disables rollback. Defaults to 10.",
 							Optional:    true,
 							Default:     10,
 						},
+						"rollback_to": {
+							Type:        schema.TypeList,
+							Description: "Rolls the deployment back to a previous revision when added or changed. The template in the state then reflects the restored revision, so update the configuration to match it or the next apply rolls forward again.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"revision": {
+										Type:         schema.TypeInt,
+										Description:  "The revision to roll back to. Defaults to 0, the revision before the current one, like `kubectl rollout undo`.",
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validateNonNegativeInteger,
+									},
+								},
+							},
+						},
 						"selector": {
-							Type:        schema.TypeMap,
-							Description: "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Type:        schema.TypeList,
+							Description: "A label query over pods that should match the Replicas count. Label keys and values that must match in order to be controlled by this deployment. If empty, it is defaulted to the `app` label of the Pod template, or to all of the Pod template labels when there is no `app` label. Changing the selector forces a new deployment. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
 							Optional:    true,
 							Computed:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(false),
+							},
 						},
 						"strategy": {
 							Type:        schema.TypeList,
@@ -108,16 +279,18 @@ func resourceKubernetesDeployment() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"max_surge": {
-													Type:        schema.TypeString,
-													Description: "max surge",
-													Optional:    true,
-													Default:     1,
+													Type:         schema.TypeString,
+													Description:  "The maximum number of pods that can be scheduled above the desired number of pods, either an absolute number, e.g. `2`, or a percentage of the desired pods, e.g. `25%`.",
+													Optional:     true,
+													Default:      "1",
+													ValidateFunc: validateIntOrPercent,
 												},
 												"max_unavailable": {
-													Type:        schema.TypeString,
-													Description: "max unavailable",
-													Optional:    true,
-													Default:     1,
+													Type:         schema.TypeString,
+													Description:  "The maximum number of pods that can be unavailable during the update, either an absolute number, e.g. `1`, or a percentage of the desired pods, e.g. `25%`.",
+													Optional:     true,
+													Default:      "1",
+													ValidateFunc: validateIntOrPercent,
 												},
 											},
 										},
@@ -162,11 +335,36 @@ func resourceKubernetesDeployment() *schema.Resource {
 								},
 							},
 						},
+						"wait_for_rollout": {
+							Type:        schema.TypeBool,
+							Description: "Wait for the rollout of the deployment to complete, i.e. all replicas are updated and available. Defaults to true. When false, creates and updates return as soon as the API server accepted the deployment.",
+							Optional:    true,
+							Default:     true,
+						},
 					},
 				},
 			},
 		},
 	}
+	s.Schema["spec"].Elem.(*schema.Resource).
+		Schema["template"].Elem.(*schema.Resource).
+		Schema["spec"].Elem.(*schema.Resource).
+		Schema["active_deadline_seconds"].ValidateFunc = validateDeploymentActiveDeadlineSeconds
+
+	return s
+}
+
+// validateDeploymentActiveDeadlineSeconds warns about pods of deployments
+// terminating themselves: they're replaced over and over and may never stay
+// ready long enough for the rollout to finish.
+func validateDeploymentActiveDeadlineSeconds(value interface{}, key string) (ws []string, es []error) {
+	ws, es = validatePositiveInteger(value, key)
+	if len(es) == 0 {
+		ws = append(ws, fmt.Sprintf("%s makes the pods of a deployment terminate after %d seconds, "+
+			"after which they fail and are replaced. This is rarely intended for long-running pods "+
+			"and can keep the rollout from finishing.", key, value.(int)))
+	}
+	return
 }
 
 func relocatedAttribute(name string) *schema.Schema {
@@ -179,7 +377,7 @@ func relocatedAttribute(name string) *schema.Schema {
 }
 
 func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
@@ -189,6 +387,11 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 	if metadata.Namespace == "" {
 		metadata.Namespace = "default"
 	}
+	err = injectDeploymentTemplateChecksums(conn, d, metadata.Namespace, &spec)
+	if err != nil {
+		return err
+	}
+	injectDeploymentRestartedAt(d, &spec)
 
 	deployment := v1beta1.Deployment{
 		ObjectMeta: metadata,
@@ -203,17 +406,11 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	log.Printf("[DEBUG] Waiting for deployment %s to schedule %d replicas",
-		d.Id(), *out.Spec.Replicas)
 	// 10 mins should be sufficient for scheduling ~10k replicas
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
-		waitForDeploymentReplicasFunc(conn, out.GetNamespace(), out.GetName()))
+	err = waitForDeployment(conn, d, out.GetNamespace(), out.GetName(), d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return err
 	}
-	// We could wait for all pods to actually reach Ready state
-	// but that means checking each pod status separately (which can be expensive at scale)
-	// as there's no aggregate data available from the API
 
 	log.Printf("[INFO] Submitted new deployment: %#v", out)
 
@@ -221,7 +418,7 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Reading deployment %s", name)
@@ -232,6 +429,12 @@ func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{})
 	}
 	log.Printf("[INFO] Received deployment: %#v", deployment)
 
+	ignored := d.Get("ignore_container_images").(*schema.Set)
+	if ignored.Len() > 0 {
+		configImages := containerImagesFromConfig(d.Get("spec.0.template.0.spec.0.container").([]interface{}))
+		overrideContainerImages(deployment.Spec.Template.Spec.Containers, configImages, ignored)
+	}
+
 	deployment.ObjectMeta.Labels = reconcileTopLevelLabels(
 		deployment.ObjectMeta.Labels,
 		expandMetadata(d.Get("metadata").([]interface{})),
@@ -242,6 +445,8 @@ func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	// Only the desired spec is flattened, never the status: the template of a
+	// paused deployment isn't rolled out yet, which mustn't show as drift
 	spec, err := flattenDeploymentSpec(deployment.Spec, d)
 	if err != nil {
 		return err
@@ -252,26 +457,78 @@ func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	err = d.Set("status", flattenDeploymentStatus(deployment.Status))
+	if err != nil {
+		return err
+	}
+
+	checksums := make(map[string]string)
+	for _, k := range deploymentTemplateChecksumAnnotations(d) {
+		if v, ok := deployment.Spec.Template.Annotations[k]; ok {
+			checksums[k] = v
+		}
+	}
+	err = d.Set("template_checksums", checksums)
+	if err != nil {
+		return err
+	}
+
+	activeReplicaSet := ""
+	rs, err := newReplicaSet(conn, deployment)
+	if err != nil {
+		// Only used for diagnostics, so don't fail the refresh, e.g. when not allowed to list replica sets
+		log.Printf("[WARN] Failed to find the replica set of the current revision of %q: %s", name, err)
+	} else if rs != nil {
+		activeReplicaSet = rs.Name
+	}
+	err = d.Set("active_replica_set", activeReplicaSet)
+	if err != nil {
+		return err
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	pullSecrets, ok, err := effectiveImagePullSecrets(conn, namespace, podSpec.ServiceAccountName, podSpec.ImagePullSecrets)
+	if err != nil {
+		// Only used for diagnostics, so don't fail the refresh, e.g. when not allowed to read service accounts
+		log.Printf("[WARN] %s", err)
+	} else if ok {
+		err = d.Set("effective_image_pull_secrets", pullSecrets)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
-	if d.HasChange("spec") {
+	if d.HasChange("spec") || d.HasChange("template_checksums") || d.HasChange("restarted_at") {
 		spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return err
 		}
+		err = injectDeploymentTemplateChecksums(conn, d, namespace, &spec)
+		if err != nil {
+			return err
+		}
+		injectDeploymentRestartedAt(d, &spec)
 
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		ignored := d.Get("ignore_container_images").(*schema.Set)
+		if ignored.Len() > 0 {
+			live, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("Failed to read deployment %q to keep its ignored container images: %s", name, err)
+			}
+			overrideContainerImages(spec.Template.Spec.Containers, containerImagesByName(live.Spec.Template.Spec.Containers), ignored)
+		}
+
+		ops = append(ops, patchDeploymentSpec("spec.0.", "/spec/", d, spec)...)
 	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
@@ -284,59 +541,234 @@ func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}
 	}
 	log.Printf("[INFO] Submitted updated deployment: %#v", out)
 
-	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
-		waitForDeploymentReplicasFunc(conn, namespace, name))
+	err = waitForDeployment(conn, d, namespace, name, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
 		return err
 	}
 
+	if d.HasChange("spec.0.rollback_to") {
+		if v, ok := d.Get("spec.0.rollback_to").([]interface{}); ok && len(v) > 0 {
+			revision := int64(d.Get("spec.0.rollback_to.0.revision").(int))
+			err = rollbackDeployment(conn, d, out, revision)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return resourceKubernetesDeploymentRead(d, meta)
 }
 
+// rollbackDeployment rolls the deployment back to the given revision, 0 being
+// the one before the current revision, and waits for the rollout. The
+// controller only reports missing revisions through events, so the revision
+// of the deployment is compared to tell whether it rolled back.
+func rollbackDeployment(conn *kubernetes.Clientset, d *schema.ResourceData, deployment *v1beta1.Deployment, revision int64) error {
+	namespace, name := deployment.Namespace, deployment.Name
+	current, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	before := current.Annotations[deploymentRevisionAnnotation]
+
+	log.Printf("[INFO] Rolling back deployment %s/%s from revision %s to revision %d", namespace, name, before, revision)
+	err = conn.ExtensionsV1beta1().Deployments(namespace).Rollback(&v1beta1.DeploymentRollback{
+		Name:       name,
+		RollbackTo: v1beta1.RollbackConfig{Revision: revision},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to roll back deployment %q: %s", name, err)
+	}
+
+	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
+		waitForDeploymentRolloutFunc(conn, namespace, name))
+	if err != nil {
+		return deploymentWaitError(conn, namespace, name, err)
+	}
+
+	rolledBack, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if rolledBack.Annotations[deploymentRevisionAnnotation] == before {
+		warnings, wErr := getLastWarningsForObject(conn, rolledBack.ObjectMeta, "Deployment", 3)
+		if wErr != nil {
+			return wErr
+		}
+		return fmt.Errorf("Deployment %q wasn't rolled back to revision %d: the revision doesn't exist or matches the current template%s",
+			name, revision, stringifyEvents(warnings))
+	}
+	log.Printf("[INFO] Deployment %s/%s rolled back to revision %d", namespace, name, revision)
+	return nil
+}
+
 func resourceKubernetesDeploymentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Deleting deployment: %#v", name)
 
-	// Drain all replicas before deleting
-	var ops PatchOperations
-	ops = append(ops, &ReplaceOperation{
-		Path:  "/spec/replicas",
-		Value: 0,
+	policy := metav1.DeletePropagationForeground
+	if v, ok := d.GetOk("delete_propagation"); ok {
+		policy = metav1.DeletionPropagation(v.(string))
+	}
+	if policy == metav1.DeletePropagationOrphan {
+		// Draining would defeat the purpose of keeping the pods
+		return deleteDeploymentOrphaningDependents(conn, d, namespace, name)
+	}
+	if !d.Get("skip_drain_on_delete").(bool) {
+		err = drainDeployment(conn, d, namespace, name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.Printf("[INFO] Deployment %s was already deleted", name)
+				d.SetId("")
+				return nil
+			}
+			return err
+		}
+	} else {
+		log.Printf("[INFO] Not draining deployment %s before deleting it", name)
+	}
+
+	err = conn.ExtensionsV1beta1().Deployments(namespace).Delete(name, &metav1.DeleteOptions{
+		PropagationPolicy: &policy,
 	})
-	data, err := ops.MarshalJSON()
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
-	_, err = conn.ExtensionsV1beta1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+
+	log.Printf("[INFO] Deployment %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+// drainDeployment scales the deployment down to zero replicas and waits until
+// all its pods are gone.
+func drainDeployment(conn *kubernetes.Clientset, d *schema.ResourceData, namespace, name string) error {
+	// Drain all replicas before deleting. A horizontal pod autoscaler may scale
+	// the deployment at the same time, so conflicts are retried.
+	deployments := conn.ExtensionsV1beta1().Deployments(namespace)
+	start := time.Now()
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), drainDeploymentFunc(
+		func() (*v1beta1.Deployment, error) {
+			return deployments.Get(name, metav1.GetOptions{})
+		},
+		func(data []byte) error {
+			_, err := deployments.Patch(name, pkgApi.JSONPatchType, data)
+			return err
+		},
+	))
 	if err != nil {
+		if !errors.IsNotFound(err) && time.Since(start) >= d.Timeout(schema.TimeoutDelete) {
+			return drainTimeoutError(name, d.Timeout(schema.TimeoutDelete), d.Timeout(schema.TimeoutDelete), err)
+		}
 		return err
 	}
 
-	// Wait until all replicas are gone
-	err = resource.Retry(d.Timeout(schema.TimeoutDelete),
-		waitForDeploymentReplicasFunc(conn, namespace, name))
+	// Wait until all replicas are gone, with a timeout of its own as scaling
+	// down is usually quick but terminating the pods may not be
+	gracePeriod := d.Get("spec.0.template.0.spec.0.termination_grace_period_seconds").(int)
+	timeout := gracePeriodAwareTimeout(d.Timeout(schema.TimeoutDelete), gracePeriod)
+	if timeout != d.Timeout(schema.TimeoutDelete) {
+		log.Printf("[WARN] Delete timeout of deployment %s (%s) is shorter than the pods' termination grace period (%ds) plus %s; waiting %s instead",
+			d.Id(), d.Timeout(schema.TimeoutDelete), gracePeriod, terminationGracePeriodBuffer, timeout)
+	}
+	start = time.Now()
+	err = resource.Retry(timeout, waitForDeploymentReplicasFunc(conn, namespace, name))
+	if err != nil && !errors.IsNotFound(err) && time.Since(start) >= timeout {
+		return drainTimeoutError(name, timeout, d.Timeout(schema.TimeoutDelete), err)
+	}
+	return err
+}
+
+// drainTimeoutError explains that draining the deployment didn't finish in
+// time, which resource.Retry only reports with the last waiting message. The
+// timeout differs from the configured one when it was extended to cover the
+// termination grace period of the pods.
+func drainTimeoutError(name string, timeout, configured time.Duration, err error) error {
+	within := timeout.String()
+	if timeout != configured {
+		within = fmt.Sprintf("%s (the delete timeout of %s, extended to the pods' termination grace period plus %s)", timeout, configured, terminationGracePeriodBuffer)
+	}
+	return fmt.Errorf("Deployment %q wasn't drained within %s, its pods may be slow to terminate or held by a finalizer: %s\n\n"+
+		"Increase the delete timeout, or set `skip_drain_on_delete` to delete the deployment without scaling it down first", name, within, err)
+}
+
+// deleteDeploymentOrphaningDependents deletes the deployment but keeps its
+// replica sets and pods running, optionally deleting them after a delay. The
+// delay counts against the delete timeout, so it must be shorter than it.
+func deleteDeploymentOrphaningDependents(conn *kubernetes.Clientset, d *schema.ResourceData, namespace, name string) error {
+	delay, cleanup := d.GetOk("orphan_cleanup_delay_seconds")
+	cleanupDelay := time.Duration(delay.(int)) * time.Second
+	if timeout := d.Timeout(schema.TimeoutDelete); cleanup && cleanupDelay >= timeout {
+		return fmt.Errorf("orphan_cleanup_delay_seconds (%s) must be shorter than the delete timeout (%s), "+
+			"increase the delete timeout to delete deployment %q", cleanupDelay, timeout, name)
+	}
+
+	deployment, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Deployment %s was already deleted", name)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
-	policy := metav1.DeletePropagationForeground
+	// Owner references are removed from the orphans, so they're looked up beforehand
+	var orphans []string
+	if cleanup {
+		rsList, err := conn.ExtensionsV1beta1().ReplicaSets(namespace).List(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to list replica sets of deployment %s: %s", name, err)
+		}
+		orphans = ownedReplicaSetNames(deployment, rsList.Items)
+	}
+
+	policy := metav1.DeletePropagationOrphan
 	err = conn.ExtensionsV1beta1().Deployments(namespace).Delete(name, &metav1.DeleteOptions{
 		PropagationPolicy: &policy,
 	})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
+	log.Printf("[INFO] Deployment %s deleted, orphaning its replica sets", name)
 
-	log.Printf("[INFO] Deployment %s deleted", name)
-
+	if len(orphans) > 0 {
+		log.Printf("[INFO] Deleting orphaned replica sets %q of deployment %s in %s", orphans, name, cleanupDelay)
+		time.Sleep(cleanupDelay)
+	}
+	background := metav1.DeletePropagationBackground
+	for _, rs := range orphans {
+		err = conn.ExtensionsV1beta1().ReplicaSets(namespace).Delete(rs, &metav1.DeleteOptions{
+			PropagationPolicy: &background,
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to delete orphaned replica set %s: %s", rs, err)
+		}
+	}
 	d.SetId("")
 	return nil
 }
 
+func ownedReplicaSetNames(deployment *v1beta1.Deployment, replicaSets []v1beta1.ReplicaSet) []string {
+	var names []string
+	for _, rs := range replicaSets {
+		for _, ref := range rs.OwnerReferences {
+			if ref.UID == deployment.UID {
+				names = append(names, rs.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
 func resourceKubernetesDeploymentExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Checking deployment %s", name)
@@ -350,7 +782,176 @@ func resourceKubernetesDeploymentExists(d *schema.ResourceData, meta interface{}
 	return true, err
 }
 
+// resourceKubernetesDeploymentCustomizeDiff runs the plan-time checks of the
+// pod template, plans the template checksums and image pull secrets and forces
+// a new deployment when its selector no longer matches the template labels.
+func resourceKubernetesDeploymentCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	err := podSpecCustomizeDiff("spec.0.template.0.spec.0.")(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = deploymentTemplateChecksumsCustomizeDiff(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = deploymentImagePullSecretsCustomizeDiff(d, meta)
+	if err != nil {
+		return err
+	}
+
+	return deploymentSelectorCustomizeDiff(d, meta)
+}
+
+// deploymentImagePullSecretsCustomizeDiff shows the image pull secrets the pods
+// will actually be able to use in the plan. They're left to be computed on
+// refresh when the service account doesn't exist yet or can't be read.
+func deploymentImagePullSecretsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	namespace := d.Get("metadata.0.namespace").(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+	serviceAccountName := d.Get("spec.0.template.0.spec.0.service_account_name").(string)
+	podSecrets := expandLocalObjectReferenceArray(d.Get("spec.0.template.0.spec.0.image_pull_secrets").([]interface{}))
+
+	conn := meta.(*kubeProvider).Clientset
+	pullSecrets, ok, err := effectiveImagePullSecrets(conn, namespace, serviceAccountName, podSecrets)
+	if err != nil {
+		// Only used for diagnostics, so don't fail the plan, e.g. when not allowed to read service accounts
+		log.Printf("[WARN] %s", err)
+		return d.SetNewComputed("effective_image_pull_secrets")
+	}
+	if !ok {
+		log.Printf("[DEBUG] Service account of deployment %s doesn't exist yet, deferring its image pull secrets", d.Id())
+		return d.SetNewComputed("effective_image_pull_secrets")
+	}
+	log.Printf("[INFO] Pods of deployment %s can pull images using the secrets %q", d.Id(), pullSecrets)
+
+	current := expandStringSlice(d.Get("effective_image_pull_secrets").([]interface{}))
+	if !reflect.DeepEqual(current, pullSecrets) {
+		return d.SetNew("effective_image_pull_secrets", pullSecrets)
+	}
+	return nil
+}
+
+// deploymentSelectorCustomizeDiff forces a new deployment when its selector no
+// longer matches the template labels, e.g. when the label a generated selector
+// was derived from changes. The API would otherwise reject the template as not
+// matching the selector, which can't be changed in place once generated.
+func deploymentSelectorCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("spec.0.template.0.metadata.0.labels") {
+		return nil
+	}
+
+	newLabels := expandStringMap(d.Get("spec.0.template.0.metadata.0.labels").(map[string]interface{}))
+	selector, err := metav1.LabelSelectorAsSelector(expandLabelSelector(d.Get("spec.0.selector").([]interface{})))
+	if err != nil {
+		return fmt.Errorf("Failed to parse the selector of deployment %s: %s", d.Id(), err)
+	}
+	if selector.Matches(labels.Set(newLabels)) {
+		return nil
+	}
+
+	log.Printf("[INFO] Selector of deployment %s no longer matches the template labels, forcing a new deployment", d.Id())
+	// ForceNew doesn't reach the attributes nested in spec, so force the new
+	// deployment through its status, which Read always sets.
+	err = d.SetNewComputed("status")
+	if err != nil {
+		return err
+	}
+	return d.ForceNew("status")
+}
+
+// drainDeploymentFunc scales the deployment down to zero replicas. On a
+// conflict the deployment is read again and patched once more, since whatever
+// scaled it in between (usually an autoscaler) may have changed the replicas.
+func drainDeploymentFunc(get func() (*v1beta1.Deployment, error), patch func([]byte) error) resource.RetryFunc {
+	return func() *resource.RetryError {
+		deployment, err := get()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+			return nil
+		}
+
+		ops := PatchOperations{
+			&ReplaceOperation{
+				Path:  "/spec/replicas",
+				Value: 0,
+			},
+		}
+		data, err := ops.MarshalJSON()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		err = patch(data)
+		if err != nil {
+			if errors.IsConflict(err) {
+				log.Printf("[DEBUG] Conflict while draining deployment %s, retrying: %s", deployment.GetName(), err)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	}
+}
+
+// terminationGracePeriodBuffer is added on top of the termination grace period
+// of pods to give the kubelet time to report them as gone.
+const terminationGracePeriodBuffer = 30 * time.Second
+
+// gracePeriodAwareTimeout returns the timeout to use when waiting for pods to
+// terminate. Pods may legitimately run for their whole grace period after being
+// signalled, so a shorter configured timeout is extended to cover it.
+func gracePeriodAwareTimeout(configured time.Duration, gracePeriodSeconds int) time.Duration {
+	minimum := time.Duration(gracePeriodSeconds)*time.Second + terminationGracePeriodBuffer
+	if configured < minimum {
+		return minimum
+	}
+	return configured
+}
+
+// waitForDeployment waits after creating or updating the deployment: for the
+// conditions of `wait_for` when set, or else for the rollout unless disabled
+// with `wait_for_rollout`. Paused deployments don't roll out.
+func waitForDeployment(conn *kubernetes.Clientset, d *schema.ResourceData, namespace, name string, timeout time.Duration) error {
+	if d.Get("spec.0.paused").(bool) {
+		log.Printf("[INFO] Deployment %s is paused, not waiting for its rollout", d.Id())
+		return nil
+	}
+
+	status := deploymentUpdateStatus
+	if conditions := expandDeploymentWaitConditions(d.Get("wait_for").([]interface{})); len(conditions) > 0 {
+		log.Printf("[DEBUG] Waiting for the conditions of deployment %s: %#v", d.Id(), conditions)
+		status = deploymentConditionsStatus(conditions)
+	} else if d.Get("spec.0.wait_for_rollout").(bool) {
+		log.Printf("[DEBUG] Waiting for the rollout of deployment %s", d.Id())
+	} else {
+		return nil
+	}
+
+	err := resource.Retry(timeout, waitForDeploymentFunc(conn, namespace, name, status))
+	if err != nil {
+		return deploymentWaitError(conn, namespace, name, err)
+	}
+	return nil
+}
+
+// waitForDeploymentReplicasFunc waits until the deployment runs the desired
+// number of replicas, old and new revisions alike. Used when draining.
 func waitForDeploymentReplicasFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return waitForDeploymentFunc(conn, ns, name, deploymentReplicasStatus)
+}
+
+// waitForDeploymentRolloutFunc waits until all the replicas of the deployment
+// run its current revision and are available.
+func waitForDeploymentRolloutFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return waitForDeploymentFunc(conn, ns, name, deploymentUpdateStatus)
+}
+
+func waitForDeploymentFunc(conn *kubernetes.Clientset, ns, name string, status func(*v1beta1.Deployment) (bool, string, error)) resource.RetryFunc {
 	return func() *resource.RetryError {
 		deployment, err := conn.ExtensionsV1beta1().Deployments(ns).Get(name, metav1.GetOptions{})
 		if err != nil {
@@ -361,12 +962,205 @@ func waitForDeploymentReplicasFunc(conn *kubernetes.Clientset, ns, name string)
 		log.Printf("[DEBUG] Current number of labelled replicas of %q: %d (of %d)\n",
 			deployment.GetName(), deployment.Status.Replicas, desiredReplicas)
 
-		if deployment.Status.Replicas == desiredReplicas {
+		done, waiting, err := status(deployment)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if done {
 			return nil
 		}
+		if pending, ok := countPendingDeploymentPods(conn, deployment); ok {
+			waiting = fmt.Sprintf("%s (%d pods of the new revision not running yet)", waiting, pending)
+		}
+		return resource.RetryableError(fmt.Errorf("%s", waiting))
+	}
+}
+
+// countPendingDeploymentPods counts the pods of the current revision of the
+// deployment which aren't running yet. Only those are listed, so it stays cheap
+// while rolling out large deployments. It returns false if the pods can't be
+// told apart yet.
+func countPendingDeploymentPods(conn *kubernetes.Clientset, deployment *v1beta1.Deployment) (int, bool) {
+	selector, err := newReplicaSetPodSelector(conn, deployment)
+	if err != nil {
+		log.Printf("[DEBUG] Failed to find the new replica set of %q: %s", deployment.GetName(), err)
+		return 0, false
+	}
+	if selector == nil {
+		return 0, false
+	}
+
+	pods, err := listPods(conn, deployment.Namespace, selector, podPhaseNotInFieldSelector(api.PodRunning, api.PodSucceeded))
+	if err != nil {
+		log.Printf("[DEBUG] Failed to list pods of %q: %s", deployment.GetName(), err)
+		return 0, false
+	}
+	return len(pods), true
+}
+
+// deploymentWaitError appends why the pods of the current revision aren't
+// coming up, i.e. scheduling failures and failing containers, as well as the
+// last warnings of the namespace to an error of the rollout wait. It is only
+// called once giving up, so the wait itself stays cheap.
+func deploymentWaitError(conn *kubernetes.Clientset, ns, name string, err error) error {
+	deployment, gErr := conn.ExtensionsV1beta1().Deployments(ns).Get(name, metav1.GetOptions{})
+	if gErr != nil {
+		log.Printf("[DEBUG] Failed to read deployment %s/%s for diagnostics: %s", ns, name, gErr)
+		return err
+	}
+
+	var diagnostics string
+	selector, sErr := newReplicaSetPodSelector(conn, deployment)
+	if sErr == nil && selector != nil {
+		unschedulable, dErr := unschedulablePodsDiagnostics(conn, ns, selector, 3)
+		if dErr != nil {
+			log.Printf("[DEBUG] Failed to gather scheduling failures of %s/%s: %s", ns, name, dErr)
+		}
+		failing, dErr := failingPodsDiagnostics(conn, ns, selector, 3)
+		if dErr != nil {
+			log.Printf("[DEBUG] Failed to gather container failures of %s/%s: %s", ns, name, dErr)
+		}
+		diagnostics = unschedulable + failing
+	}
 
-		return resource.RetryableError(fmt.Errorf("Waiting for %d replicas of %q to be scheduled (%d)",
-			desiredReplicas, deployment.GetName(), deployment.Status.Replicas))
+	warnings, wErr := getLastWarningsInNamespace(conn, ns, 3)
+	if wErr != nil {
+		log.Printf("[DEBUG] Failed to read the last warnings in %s: %s", ns, wErr)
+	} else if len(warnings) > 0 {
+		diagnostics += fmt.Sprintf("\n\nLast warnings in namespace %q:%s", ns, stringifyEvents(warnings))
+	}
+
+	return fmt.Errorf("%s%s", err, diagnostics)
+}
+
+// Reasons of the Progressing condition set by the deployment controller
+const (
+	deploymentProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+	deploymentNewReplicaSetAvailableReason   = "NewReplicaSetAvailable"
+	deploymentPausedReason                   = "DeploymentPaused"
+)
+
+// quotaExceededMessage is part of the message of the pod creation failures
+// caused by a resource quota, which the deployment controller copies from the
+// ReplicaFailure condition of the replica set.
+const quotaExceededMessage = "exceeded quota"
+
+// deploymentRolloutStatus tells whether the replicas of the deployment are
+// scheduled and the controller finished rolling them out. The controller's
+// progress deadline is authoritative: exceeding it fails the wait right away,
+// whatever is left of the Terraform timeout.
+func deploymentRolloutStatus(deployment *v1beta1.Deployment) (bool, string, error) {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, fmt.Sprintf("Waiting for the rollout of %q to be observed by the controller", deployment.GetName()), nil
+	}
+
+	var progressing *v1beta1.DeploymentCondition
+	for i, c := range deployment.Status.Conditions {
+		if c.Type == v1beta1.DeploymentProgressing {
+			progressing = &deployment.Status.Conditions[i]
+		}
+		// The controller keeps retrying, but the pods won't come up until the quota is raised
+		if c.Type == v1beta1.DeploymentReplicaFailure && c.Status == api.ConditionTrue && strings.Contains(c.Message, quotaExceededMessage) {
+			return false, "", fmt.Errorf("Deployment %q can't create its pods, a resource quota of namespace %q is exceeded: %s",
+				deployment.GetName(), deployment.GetNamespace(), c.Message)
+		}
+	}
+	if progressing != nil && progressing.Reason == deploymentProgressDeadlineExceededReason {
+		deadline := "the configured"
+		if deployment.Spec.ProgressDeadlineSeconds != nil {
+			deadline = fmt.Sprintf("%ds", *deployment.Spec.ProgressDeadlineSeconds)
+		}
+		return false, "", fmt.Errorf("Deployment %q exceeded its progress deadline of %s: %s",
+			deployment.GetName(), deadline, progressing.Message)
+	}
+
+	desiredReplicas := *deployment.Spec.Replicas
+	if deployment.Status.Replicas != desiredReplicas {
+		return false, fmt.Sprintf("Waiting for %d replicas of %q to be scheduled (%d)",
+			desiredReplicas, deployment.GetName(), deployment.Status.Replicas), nil
+	}
+
+	if progressing != nil && progressing.Reason != deploymentNewReplicaSetAvailableReason && progressing.Reason != deploymentPausedReason {
+		return false, fmt.Sprintf("Waiting for the rollout of %q to finish: %s", deployment.GetName(), progressing.Message), nil
+	}
+
+	return true, "", nil
+}
+
+// deploymentReplicasStatus only tells whether the deployment runs the desired
+// number of replicas. Unlike deploymentRolloutStatus, it ignores the conditions
+// of the deployment: scaling doesn't refresh them, so a drain would otherwise
+// fail on a progress deadline the last rollout exceeded, or on a quota the
+// pods being removed exceeded.
+func deploymentReplicasStatus(deployment *v1beta1.Deployment) (bool, string, error) {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, fmt.Sprintf("Waiting for the scaling of %q to be observed by the controller", deployment.GetName()), nil
+	}
+
+	desiredReplicas := *deployment.Spec.Replicas
+	if deployment.Status.Replicas != desiredReplicas {
+		return false, fmt.Sprintf("Waiting for %d replicas of %q to be running (%d)",
+			desiredReplicas, deployment.GetName(), deployment.Status.Replicas), nil
+	}
+	return true, "", nil
+}
+
+// deploymentUpdateStatus is stricter than deploymentRolloutStatus: old pods
+// count as replicas during a rolling update, so it also requires all the
+// replicas to be updated and available. Paused deployments don't roll out.
+func deploymentUpdateStatus(deployment *v1beta1.Deployment) (bool, string, error) {
+	done, waiting, err := deploymentRolloutStatus(deployment)
+	if err != nil || !done {
+		return done, waiting, err
+	}
+	if deployment.Spec.Paused {
+		return true, "", nil
+	}
+
+	desiredReplicas := *deployment.Spec.Replicas
+	if deployment.Status.UpdatedReplicas != desiredReplicas {
+		return false, fmt.Sprintf("Waiting for %d replicas of %q to be updated (%d)",
+			desiredReplicas, deployment.GetName(), deployment.Status.UpdatedReplicas), nil
+	}
+	if deployment.Status.AvailableReplicas != desiredReplicas {
+		return false, fmt.Sprintf("Waiting for %d replicas of %q to be available (%d)",
+			desiredReplicas, deployment.GetName(), deployment.Status.AvailableReplicas), nil
+	}
+	return true, "", nil
+}
+
+// deploymentConditionsStatus returns a status of waitForDeploymentFunc telling
+// whether the deployment has all the given conditions with their status.
+// Conditions are only trusted once the controller observed the current
+// generation, and exceeding the progress deadline fails the wait like
+// deploymentRolloutStatus, unless that is what is waited for.
+func deploymentConditionsStatus(conditions []v1beta1.DeploymentCondition) func(*v1beta1.Deployment) (bool, string, error) {
+	return func(deployment *v1beta1.Deployment) (bool, string, error) {
+		if deployment.Status.ObservedGeneration < deployment.Generation {
+			return false, fmt.Sprintf("Waiting for the rollout of %q to be observed by the controller", deployment.GetName()), nil
+		}
+
+		current := make(map[v1beta1.DeploymentConditionType]v1beta1.DeploymentCondition, len(deployment.Status.Conditions))
+		for _, c := range deployment.Status.Conditions {
+			current[c.Type] = c
+		}
+		for _, want := range conditions {
+			c, ok := current[want.Type]
+			if ok && c.Status == want.Status {
+				continue
+			}
+			if progressing, ok := current[v1beta1.DeploymentProgressing]; ok && progressing.Reason == deploymentProgressDeadlineExceededReason {
+				return false, "", fmt.Errorf("Deployment %q exceeded its progress deadline before condition %s was %s: %s",
+					deployment.GetName(), want.Type, want.Status, progressing.Message)
+			}
+			given := "not reported yet"
+			if ok {
+				given = fmt.Sprintf("%s: %s", c.Status, c.Message)
+			}
+			return false, fmt.Sprintf("Waiting for condition %s of %q to be %s (%s)",
+				want.Type, deployment.GetName(), want.Status, given), nil
+		}
+		return true, "", nil
 	}
 }
 
@@ -383,9 +1177,20 @@ func resourceKubernetesDeploymentStateUpgrader(
 	case 0:
 		log.Println("[INFO] Found Kubernetes Deployment State v0; migrating to v1")
 		is, err = migrateStateV0toV1(is)
+		if err != nil {
+			return is, err
+		}
+		fallthrough
 	case 1:
 		log.Println("[INFO] Found Kubernetes Deployment State v1; migrating to v2")
 		is, err = migrateStateV1toV2(is)
+		if err != nil {
+			return is, err
+		}
+		fallthrough
+	case 2:
+		log.Println("[INFO] Found Kubernetes Deployment State v2; migrating to v3")
+		is, err = migrateStateSelectorMapToBlock(is)
 
 	default:
 		return is, fmt.Errorf("Unexpected schema version: %d", v)
@@ -448,3 +1253,139 @@ func migrateStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState,
 	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
 	return is, nil
 }
+
+// The selector was originally a map of labels to match. It's now a block
+// supporting both match_labels and match_expressions.
+func migrateStateSelectorMapToBlock(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	const oldPrefix = "spec.0.selector."
+	const newPrefix = "spec.0.selector.0.match_labels."
+
+	newSelector := make(map[string]string)
+	for k, v := range is.Attributes {
+		if !strings.HasPrefix(k, oldPrefix) {
+			continue
+		}
+		newK := newPrefix + strings.TrimPrefix(k, oldPrefix)
+		newSelector[newK] = v
+		log.Printf("[DEBUG] moved attribute %s -> %s ", k, newK)
+		delete(is.Attributes, k)
+	}
+
+	if len(newSelector) > 0 {
+		for k, v := range newSelector {
+			is.Attributes[k] = v
+		}
+		is.Attributes["spec.0.selector.#"] = "1"
+		is.Attributes["spec.0.selector.0.match_expressions.#"] = "0"
+	}
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+// deploymentTemplateChecksumsCustomizeDiff plans new template checksums when
+// the content of the objects in `template_annotations_from` or
+// `config_checksum_annotations` changed. They're all left to be computed on
+// apply if any of the objects doesn't exist yet.
+func deploymentTemplateChecksumsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	current := expandStringMap(d.Get("template_checksums").(map[string]interface{}))
+	refs := d.Get("template_annotations_from").([]interface{})
+	annotations := d.Get("config_checksum_annotations").(map[string]interface{})
+	if len(refs) == 0 && len(annotations) == 0 {
+		if len(current) > 0 {
+			return d.SetNew("template_checksums", map[string]string{})
+		}
+		return nil
+	}
+
+	namespace := d.Get("metadata.0.namespace").(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+	groups := expandTemplateAnnotationsFrom(refs, namespace)
+	err := expandConfigChecksumAnnotations(annotations, namespace, groups)
+	if err != nil {
+		return err
+	}
+	conn := meta.(*kubeProvider).Clientset
+	checksums, ok, err := checksumTemplateAnnotations(conn, groups)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Printf("[DEBUG] Objects referenced by deployment %s don't exist yet, deferring the template checksums", d.Id())
+		return d.SetNewComputed("template_checksums")
+	}
+	if !reflect.DeepEqual(checksums, current) {
+		return d.SetNew("template_checksums", checksums)
+	}
+	return nil
+}
+
+// injectDeploymentTemplateChecksums sets the planned template checksums as pod
+// template annotations, computing them if they were deferred at plan time.
+func injectDeploymentTemplateChecksums(conn *kubernetes.Clientset, d *schema.ResourceData, namespace string, spec *v1beta1.DeploymentSpec) error {
+	refs := d.Get("template_annotations_from").([]interface{})
+	annotations := d.Get("config_checksum_annotations").(map[string]interface{})
+	if len(refs) == 0 && len(annotations) == 0 {
+		return nil
+	}
+
+	groups := expandTemplateAnnotationsFrom(refs, namespace)
+	err := expandConfigChecksumAnnotations(annotations, namespace, groups)
+	if err != nil {
+		return err
+	}
+	checksums := expandStringMap(d.Get("template_checksums").(map[string]interface{}))
+	if len(checksums) != len(groups) {
+		var ok bool
+		checksums, ok, err = checksumTemplateAnnotations(conn, groups)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("Failed to compute the template checksums of deployment %s: a referenced object doesn't exist", namespace+"/"+d.Get("metadata.0.name").(string))
+		}
+	}
+
+	if spec.Template.Annotations == nil {
+		spec.Template.Annotations = make(map[string]string)
+	}
+	for k, v := range checksums {
+		spec.Template.Annotations[k] = v
+	}
+	return nil
+}
+
+// deploymentTemplateChecksumAnnotations returns the pod template annotations
+// managed through `template_annotations_from` and `config_checksum_annotations`.
+func deploymentTemplateChecksumAnnotations(d *schema.ResourceData) []string {
+	annotations := []string{templateChecksumAnnotation}
+	for _, v := range d.Get("template_annotations_from").([]interface{}) {
+		if a, ok := v.(map[string]interface{})["annotation"].(string); ok && a != "" {
+			annotations = append(annotations, a)
+		}
+	}
+	for k := range d.Get("config_checksum_annotations").(map[string]interface{}) {
+		annotations = append(annotations, k)
+	}
+	return annotations
+}
+
+// restartedAtAnnotation is the pod template annotation set by `kubectl rollout restart`
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// injectDeploymentRestartedAt sets the restart trigger on the pod template.
+// The annotation is internal, so it's left out of the template metadata on read.
+func injectDeploymentRestartedAt(d *schema.ResourceData, spec *v1beta1.DeploymentSpec) {
+	restartedAt := d.Get("restarted_at").(string)
+	if restartedAt == "" {
+		return
+	}
+	if spec.Template.Annotations == nil {
+		spec.Template.Annotations = make(map[string]string)
+	}
+	spec.Template.Annotations[restartedAtAnnotation] = restartedAt
+}
@@ -2,14 +2,20 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	api "k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
 
@@ -30,6 +36,65 @@ func TestAccKubernetesDeployment_minimal(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "metadata.0.name", name),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
+					resource.TestMatchResourceAttr("kubernetes_deployment.test", "active_replica_set", regexp.MustCompile("^"+name+"-")),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "status.0.available_replicas", "20"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "status.0.unavailable_replicas", "0"),
+					resource.TestCheckResourceAttrSet("kubernetes_deployment.test", "status.0.observed_generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_deployment.test", "status.0.condition.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_waitForRolloutTimeout(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDeploymentConfig_waitForRollout(name, true),
+				ExpectError: regexp.MustCompile("Waiting for the rollout of .* to finish"),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_quotaExceeded(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDeploymentConfig_quotaExceeded(name),
+				ExpectError: regexp.MustCompile("a resource quota of namespace .* is exceeded: .*exceeded quota"),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_noWaitForRollout(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_waitForRollout(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.wait_for_rollout", "false"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "status.0.available_replicas", "0"),
 				),
 			},
 		},
@@ -120,6 +185,259 @@ func TestAccKubernetesDeployment_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesDeployment_pausedTemplateChange(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_paused(name, "nginx:1.7.8"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.paused", "true"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
+				),
+			},
+			// The changed template isn't rolled out while paused, which must not show as drift
+			{
+				Config: testAccKubernetesDeploymentConfig_paused(name, "nginx:1.7.9"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.paused", "true"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.9"),
+					testAccCheckDeploymentUID(&conf1, &conf2, true),
+					func(s *terraform.State) error {
+						if image := conf2.Spec.Template.Spec.Containers[0].Image; image != "nginx:1.7.9" {
+							return fmt.Errorf("Expected the template of the paused deployment to be updated, got image %q", image)
+						}
+						before := conf1.Annotations[deploymentRevisionAnnotation]
+						after := conf2.Annotations[deploymentRevisionAnnotation]
+						if before != after {
+							return fmt.Errorf("Expected the paused deployment not to roll out, revision changed from %q to %q", before, after)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_pauseAndResume(t *testing.T) {
+	var conf1, conf2, conf3 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_pausable(name, "nginx:1.7.8", false, "10m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.paused", "false"),
+				),
+			},
+			// The update timeout is shorter than a rollout, which isn't waited for while paused
+			{
+				Config: testAccKubernetesDeploymentConfig_pausable(name, "nginx:1.7.9", true, "5s"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.paused", "true"),
+					testAccCheckDeploymentUID(&conf1, &conf2, true),
+					func(s *terraform.State) error {
+						if !conf2.Spec.Paused {
+							return fmt.Errorf("Expected the deployment to be paused")
+						}
+						before := conf1.Annotations[deploymentRevisionAnnotation]
+						after := conf2.Annotations[deploymentRevisionAnnotation]
+						if before != after {
+							return fmt.Errorf("Expected the paused deployment not to roll out, revision changed from %q to %q", before, after)
+						}
+						return nil
+					},
+				),
+			},
+			// Resuming only patches the paused flag and then rolls out the new template
+			{
+				Config: testAccKubernetesDeploymentConfig_pausable(name, "nginx:1.7.9", false, "10m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf3),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.paused", "false"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "status.0.updated_replicas", "2"),
+					testAccCheckDeploymentUID(&conf2, &conf3, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_deletePropagationOrphan(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			err := testAccCheckKubernetesDeploymentDestroy(s)
+			if err != nil {
+				return err
+			}
+			// The orphans are deleted asynchronously by the garbage collector otherwise
+			time.Sleep(10 * time.Second)
+			defer testAccDeleteReplicaSets(name)
+			return testAccCheckReplicaSetsCount(name, 1)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_deletePropagation(name, "Orphan", 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "delete_propagation", "Orphan"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_deletePropagationOrphanCleanup(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			err := testAccCheckKubernetesDeploymentDestroy(s)
+			if err != nil {
+				return err
+			}
+			defer testAccDeleteReplicaSets(name)
+			return testAccCheckReplicaSetsCount(name, 0)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_deletePropagation(name, "Orphan", 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "orphan_cleanup_delay_seconds", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_skipDrainOnDelete(t *testing.T) {
+	var conf v1beta1.Deployment
+	var destroyStarted time.Time
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_skipDrainOnDelete(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "skip_drain_on_delete", "true"),
+				),
+			},
+			{
+				// The pods ignore SIGTERM, so draining would wait for their 2 minute grace period
+				PreConfig: func() { destroyStarted = time.Now() },
+				Config:    testAccKubernetesDeploymentConfig_skipDrainOnDelete(name),
+				Destroy:   true,
+				Check: func(s *terraform.State) error {
+					if elapsed := time.Since(destroyStarted); elapsed > time.Minute {
+						return fmt.Errorf("Expected the deployment to be deleted without draining it, took %s", elapsed)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestOwnedReplicaSetNames(t *testing.T) {
+	deployment := &v1beta1.Deployment{ObjectMeta: meta_v1.ObjectMeta{UID: "deployment-uid"}}
+	replicaSet := func(name string, owner pkgApi.UID) v1beta1.ReplicaSet {
+		rs := v1beta1.ReplicaSet{ObjectMeta: meta_v1.ObjectMeta{Name: name}}
+		if owner != "" {
+			rs.OwnerReferences = []meta_v1.OwnerReference{{Kind: "Deployment", UID: owner}}
+		}
+		return rs
+	}
+
+	out := ownedReplicaSetNames(deployment, []v1beta1.ReplicaSet{
+		replicaSet("web-1111", "deployment-uid"),
+		replicaSet("web-2222", "other-uid"),
+		replicaSet("web-3333", ""),
+		replicaSet("web-4444", "deployment-uid"),
+	})
+	expected := []string{"web-1111", "web-4444"}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected replica sets.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestAccKubernetesDeployment_rollback(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.7.8", 1, ""),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.7.9", 2, ""),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.8.0", 2, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.8.0"),
+				),
+			},
+			// The configured template is left as is, so it shows as a change afterwards
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.8.0", 2, "0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.rollback_to.0.revision", "0"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.9"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// Adopting the restored template doesn't roll back again
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.7.9", 2, "0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.9"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesDeployment_importBasic(t *testing.T) {
 	resourceName := "kubernetes_deployment.test"
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -202,6 +520,32 @@ func TestAccKubernetesDeployment_initContainer(t *testing.T) {
 		},
 	})
 }
+func TestAccKubernetesDeployment_optionalConfigMapVolume(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			// The config map doesn't exist, pods must start anyway
+			{
+				Config: testAccKubernetesDeploymentWithOptionalConfigMapVolume(name, "nginx:1.7.8"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.volume.0.config_map.0.optional", "true"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.volume.0.config_map.0.default_mode", "420"), // 0644 in decimal
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.volume.0.config_map.0.items.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.volume.0.config_map.0.items.0.mode", "0"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.volume.0.config_map.0.items.1.mode", "256"), // 0400 in decimal
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesDeployment_noTopLevelLabels(t *testing.T) {
 	var conf v1beta1.Deployment
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -223,288 +567,1917 @@ func TestAccKubernetesDeployment_noTopLevelLabels(t *testing.T) {
 	})
 }
 
-func pause() resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		time.Sleep(1 * time.Minute)
-		return nil
-	}
-}
-
-func testAccCheckKubernetesDeploymentDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+func TestAccKubernetesDeployment_generatedSelector(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "kubernetes_deployment" {
-			continue
-		}
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_generatedSelector(name, "one", "frontend"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.app", "one"),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_generatedSelector(name, "one", "backend"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.app", "one"),
+					testAccCheckDeploymentUID(&conf1, &conf2, true),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_generatedSelector(name, "two", "backend"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.app", "two"),
+					testAccCheckDeploymentUID(&conf1, &conf2, false),
+				),
+			},
+		},
+	})
+}
 
-		namespace, name, err := idParts(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
+func TestAccKubernetesDeployment_matchExpressions(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 
-		resp, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, meta_v1.GetOptions{})
-		if err == nil {
-			if resp.Name == rs.Primary.ID {
-				return fmt.Errorf("Deployment still exists: %s", rs.Primary.ID)
-			}
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_matchExpressions(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_labels.app", "web"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_expressions.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_expressions.0.key", "tier"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_expressions.0.operator", "In"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.0.match_expressions.0.values.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_templateAnnotationsFrom(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	// The config map is managed outside of Terraform, so that its changes are seen at plan time
+	cfgMapName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			testAccDeleteConfigMap(cfgMapName)
+			return testAccCheckKubernetesDeploymentDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccPutConfigMap(t, cfgMapName, map[string]string{"level": "info"}),
+				Config:    testAccKubernetesDeploymentConfig_templateAnnotationsFrom(name, cfgMapName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "template_checksums.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.%", "0"),
+					testAccCheckDeploymentTemplateChecksum(&conf1, templateChecksumAnnotation),
+				),
+			},
+			{
+				PreConfig: testAccPutConfigMap(t, cfgMapName, map[string]string{"level": "debug"}),
+				Config:    testAccKubernetesDeploymentConfig_templateAnnotationsFrom(name, cfgMapName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					testAccCheckDeploymentTemplateChecksum(&conf2, templateChecksumAnnotation),
+					func(s *terraform.State) error {
+						if conf1.Spec.Template.Annotations[templateChecksumAnnotation] == conf2.Spec.Template.Annotations[templateChecksumAnnotation] {
+							return fmt.Errorf("Expected the template checksum to change with the config map")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_templateAnnotationsFromAnnotation(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	// The config map is managed outside of Terraform, so that its changes are seen at plan time
+	cfgMapName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			testAccDeleteConfigMap(cfgMapName)
+			return testAccCheckKubernetesDeploymentDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccPutConfigMap(t, cfgMapName, map[string]string{"level": "info"}),
+				Config:    testAccKubernetesDeploymentConfig_templateAnnotationsFromAnnotation(name, cfgMapName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "template_checksums.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.description", "web"),
+					testAccCheckDeploymentTemplateChecksum(&conf1, "checksum/config"),
+				),
+			},
+			{
+				PreConfig: testAccPutConfigMap(t, cfgMapName, map[string]string{"level": "debug"}),
+				Config:    testAccKubernetesDeploymentConfig_templateAnnotationsFromAnnotation(name, cfgMapName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					testAccCheckDeploymentTemplateChecksum(&conf2, "checksum/config"),
+					func(s *terraform.State) error {
+						if conf1.Spec.Template.Annotations["checksum/config"] == conf2.Spec.Template.Annotations["checksum/config"] {
+							return fmt.Errorf("Expected the checksum/config annotation to change with the config map")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_configChecksumAnnotations(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	// The config map is managed outside of Terraform, so that its changes are seen at plan time
+	cfgMapName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			testAccDeleteConfigMap(cfgMapName)
+			return testAccCheckKubernetesDeploymentDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccPutConfigMap(t, cfgMapName, map[string]string{"level": "info"}),
+				Config:    testAccKubernetesDeploymentConfig_configChecksumAnnotations(name, cfgMapName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "template_checksums.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.description", "web"),
+					testAccCheckDeploymentTemplateChecksum(&conf1, "checksum/config"),
+				),
+			},
+			{
+				PreConfig: testAccPutConfigMap(t, cfgMapName, map[string]string{"level": "debug"}),
+				Config:    testAccKubernetesDeploymentConfig_configChecksumAnnotations(name, cfgMapName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					testAccCheckDeploymentTemplateChecksum(&conf2, "checksum/config"),
+					func(s *terraform.State) error {
+						if conf1.Spec.Template.Annotations["checksum/config"] == conf2.Spec.Template.Annotations["checksum/config"] {
+							return fmt.Errorf("Expected the config checksum to change with the config map")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_ignoreContainerImages(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_ignoreContainerImages(name, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
+				),
+			},
+			// The image is changed by another tool, which must neither show in the plan nor be reverted
+			{
+				PreConfig: testAccSetDeploymentImage(t, name, "nginx:1.7.9"),
+				Config:    testAccKubernetesDeploymentConfig_ignoreContainerImages(name, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
+					func(s *terraform.State) error {
+						image := conf.Spec.Template.Spec.Containers[0].Image
+						if image != "nginx:1.7.9" {
+							return fmt.Errorf("Expected the ignored image to be kept as nginx:1.7.9, got %s", image)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_progressDeadlineExceeded(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDeploymentConfig_progressDeadline(name, "nginx:tf-acc-test-missing-tag"),
+				ExpectError: regexp.MustCompile("exceeded its progress deadline of 15s"),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_restartedAt(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_restartedAt(name, "2018-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "restarted_at", "2018-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.%", "0"),
+					testAccCheckDeploymentRestartedAt(&conf1, "2018-01-01T00:00:00Z"),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_restartedAt(name, "2018-01-02T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "restarted_at", "2018-01-02T00:00:00Z"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.%", "0"),
+					testAccCheckDeploymentRestartedAt(&conf2, "2018-01-02T00:00:00Z"),
+					testAccCheckDeploymentUID(&conf1, &conf2, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_affinity(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_affinity(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.node_affinity.0.required_during_scheduling_ignored_during_execution.0.node_selector_term.0.match_expressions.0.key", "beta.kubernetes.io/os"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.node_affinity.0.required_during_scheduling_ignored_during_execution.0.node_selector_term.0.match_expressions.0.operator", "In"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.0.weight", "100"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.0.pod_affinity_term.0.topology_key", "kubernetes.io/hostname"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.1.weight", "50"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.1.pod_affinity_term.0.topology_key", "failure-domain.beta.kubernetes.io/zone"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_scaleWithoutRollout(t *testing.T) {
+	var conf1, conf2 v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.7.8", 1, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf1),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_rollback(name, "nginx:1.7.8", 3, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.replicas", "3"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "status.0.updated_replicas", "3"),
+					testAccCheckDeploymentUID(&conf1, &conf2, true),
+					func(s *terraform.State) error {
+						before := conf1.Annotations[deploymentRevisionAnnotation]
+						after := conf2.Annotations[deploymentRevisionAnnotation]
+						if before != after {
+							return fmt.Errorf("Expected scaling not to roll out, revision changed from %q to %q", before, after)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_waitForAvailable(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_waitForAvailable(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "wait_for.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "wait_for.0.condition", "Available"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "wait_for.0.status", "True"),
+					func(s *terraform.State) error {
+						for _, c := range conf.Status.Conditions {
+							if c.Type == v1beta1.DeploymentAvailable && c.Status == api.ConditionTrue {
+								return nil
+							}
+						}
+						return fmt.Errorf("Expected the deployment to be available once created, given conditions: %#v", conf.Status.Conditions)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_revisionHistoryLimitZero(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_revisionHistoryLimitZero(name, "nginx:1.7.8"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.revision_history_limit", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_revisionHistoryLimitZero(name, "nginx:1.7.9"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.revision_history_limit", "0"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.9"),
+					func(s *terraform.State) error {
+						// The old replica set is removed once scaled down
+						time.Sleep(10 * time.Second)
+						return testAccCheckReplicaSetsCount(name, 1)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_serverDefaults(t *testing.T) {
+	var conf v1beta1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_serverDefaults(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image_pull_policy", "IfNotPresent"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.termination_message_path", "/dev/termination-log"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.port.0.protocol", "TCP"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.port.0.host_port", "8080"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.volume.0.downward_api.0.default_mode", "420"),
+				),
+			},
+			{
+				Config:   testAccKubernetesDeploymentConfig_serverDefaults(name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestResourceKubernetesDeployment_activeDeadlineSecondsWarning(t *testing.T) {
+	cases := []struct {
+		Name     string
+		PodSpec  map[string]interface{}
+		Warnings int
+	}{
+		{
+			"without active deadline",
+			map[string]interface{}{},
+			0,
+		},
+		{
+			"with active deadline",
+			map[string]interface{}{"active_deadline_seconds": 300},
+			1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			podSpec := map[string]interface{}{
+				"container": []map[string]interface{}{
+					{"name": "web", "image": "nginx:1.7.8"},
+				},
+			}
+			for k, v := range tc.PodSpec {
+				podSpec[k] = v
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"template": []map[string]interface{}{{
+						"spec": []map[string]interface{}{podSpec},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ws, es := resourceKubernetesDeployment().Validate(terraform.NewResourceConfig(raw))
+			if len(es) > 0 {
+				t.Fatalf("Unexpected errors: %v", es)
+			}
+			if len(ws) != tc.Warnings {
+				t.Fatalf("Expected %d warnings, got: %q", tc.Warnings, ws)
+			}
+			for _, w := range ws {
+				if !strings.Contains(w, "active_deadline_seconds") {
+					t.Fatalf("Expected the warning to name the attribute, got: %q", w)
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	progressing := func(reason string) []v1beta1.DeploymentCondition {
+		return []v1beta1.DeploymentCondition{
+			{Type: v1beta1.DeploymentAvailable, Reason: "MinimumReplicasAvailable"},
+			{Type: v1beta1.DeploymentProgressing, Reason: reason, Message: "ReplicaSet \"web-1\" is progressing."},
 		}
 	}
+	cases := []struct {
+		Name               string
+		Generation         int64
+		ObservedGeneration int64
+		Replicas           int32
+		Conditions         []v1beta1.DeploymentCondition
+		Done               bool
+		ExpectError        bool
+	}{
+		{"complete", 2, 2, 3, progressing("NewReplicaSetAvailable"), true, false},
+		{"no condition", 2, 2, 3, nil, true, false},
+		{"paused", 2, 2, 3, progressing("DeploymentPaused"), true, false},
+		{"not observed yet", 3, 2, 3, progressing("NewReplicaSetAvailable"), false, false},
+		{"not scheduled yet", 2, 2, 1, progressing("NewReplicaSetAvailable"), false, false},
+		{"rolling out", 2, 2, 3, progressing("ReplicaSetUpdated"), false, false},
+		{"deadline exceeded", 2, 2, 1, progressing("ProgressDeadlineExceeded"), false, true},
+		{"quota exceeded", 2, 2, 1, append(progressing("ReplicaSetUpdated"), v1beta1.DeploymentCondition{
+			Type:    v1beta1.DeploymentReplicaFailure,
+			Status:  api.ConditionTrue,
+			Reason:  "FailedCreate",
+			Message: "pods \"web-1-x2v9z\" is forbidden: exceeded quota: tiny, requested: pods=1, used: pods=1, limited: pods=1",
+		}), false, true},
+		{"other replica failure", 2, 2, 1, append(progressing("ReplicaSetUpdated"), v1beta1.DeploymentCondition{
+			Type:    v1beta1.DeploymentReplicaFailure,
+			Status:  api.ConditionTrue,
+			Reason:  "FailedCreate",
+			Message: "Internal error occurred: failed calling admission webhook",
+		}), false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deployment := &v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "web", Generation: tc.Generation},
+				Spec: v1beta1.DeploymentSpec{
+					Replicas:                ptrToInt32(3),
+					ProgressDeadlineSeconds: ptrToInt32(60),
+				},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: tc.ObservedGeneration,
+					Replicas:           tc.Replicas,
+					Conditions:         tc.Conditions,
+				},
+			}
+			done, waiting, err := deploymentRolloutStatus(deployment)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != tc.Done {
+				t.Fatalf("Expected done to be %t (%s)", tc.Done, waiting)
+			}
+		})
+	}
+}
+
+func TestDeploymentReplicasStatus(t *testing.T) {
+	failed := []v1beta1.DeploymentCondition{
+		{Type: v1beta1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded", Message: "ReplicaSet \"web-2\" has timed out progressing."},
+		{
+			Type:    v1beta1.DeploymentReplicaFailure,
+			Status:  api.ConditionTrue,
+			Reason:  "FailedCreate",
+			Message: "pods \"web-2-x2v9z\" is forbidden: exceeded quota: tiny, requested: pods=1, used: pods=1, limited: pods=1",
+		},
+	}
+	cases := []struct {
+		Name               string
+		Generation         int64
+		ObservedGeneration int64
+		Replicas           int32
+		Conditions         []v1beta1.DeploymentCondition
+		Done               bool
+	}{
+		{"drained", 3, 3, 0, nil, true},
+		{"drained after a failed rollout", 3, 3, 0, failed, true},
+		{"draining after a failed rollout", 3, 3, 2, failed, false},
+		{"scaling not observed yet", 3, 2, 0, failed, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deployment := &v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "web", Generation: tc.Generation},
+				Spec: v1beta1.DeploymentSpec{
+					Replicas:                ptrToInt32(0),
+					ProgressDeadlineSeconds: ptrToInt32(60),
+				},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: tc.ObservedGeneration,
+					Replicas:           tc.Replicas,
+					Conditions:         tc.Conditions,
+				},
+			}
+			done, waiting, err := deploymentReplicasStatus(deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != tc.Done {
+				t.Fatalf("Expected done to be %t (%s)", tc.Done, waiting)
+			}
+		})
+	}
+}
+
+func TestDrainDeploymentFunc(t *testing.T) {
+	conflict := errors.NewConflict(v1beta1.Resource("deployments"), "web", fmt.Errorf("the object has been modified"))
+
+	cases := []struct {
+		Name            string
+		Replicas        int32
+		PatchErrors     []error
+		ExpectedPatches int
+		ExpectError     bool
+	}{
+		{"drained", 3, nil, 1, false},
+		{"already drained", 0, nil, 0, false},
+		{"conflict scaled by autoscaler", 3, []error{conflict, conflict}, 3, false},
+		{"other error", 3, []error{fmt.Errorf("forbidden")}, 1, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			gets, patches := 0, 0
+			get := func() (*v1beta1.Deployment, error) {
+				gets++
+				return &v1beta1.Deployment{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "web"},
+					Spec:       v1beta1.DeploymentSpec{Replicas: ptrToInt32(tc.Replicas)},
+				}, nil
+			}
+			patch := func(data []byte) error {
+				patches++
+				if string(data) != `[{"path":"/spec/replicas","value":0,"op":"replace"}]` {
+					t.Errorf("Unexpected patch: %s", data)
+				}
+				if patches <= len(tc.PatchErrors) {
+					return tc.PatchErrors[patches-1]
+				}
+				return nil
+			}
+
+			err := resource.Retry(10*time.Second, drainDeploymentFunc(get, patch))
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				if errors.IsConflict(err) {
+					t.Fatalf("Expected a non-conflict error, got %s", err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if patches != tc.ExpectedPatches {
+				t.Fatalf("Expected %d patches, got %d", tc.ExpectedPatches, patches)
+			}
+			// The deployment is read again before every attempt
+			if gets != patches && tc.ExpectedPatches > 0 {
+				t.Fatalf("Expected %d reads, got %d", patches, gets)
+			}
+		})
+	}
+}
+
+func TestDeploymentUpdateStatus(t *testing.T) {
+	cases := []struct {
+		Name              string
+		Paused            bool
+		Replicas          int32
+		UpdatedReplicas   int32
+		AvailableReplicas int32
+		Done              bool
+	}{
+		{"rolled out", false, 3, 3, 3, true},
+		{"old pods still running", false, 3, 1, 3, false},
+		{"new pods not available yet", false, 3, 3, 1, false},
+		{"paused", true, 3, 1, 3, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deployment := &v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "web", Generation: 2},
+				Spec: v1beta1.DeploymentSpec{
+					Paused:   tc.Paused,
+					Replicas: ptrToInt32(3),
+				},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           tc.Replicas,
+					UpdatedReplicas:    tc.UpdatedReplicas,
+					AvailableReplicas:  tc.AvailableReplicas,
+				},
+			}
+			done, waiting, err := deploymentUpdateStatus(deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != tc.Done {
+				t.Fatalf("Expected done to be %t (%s)", tc.Done, waiting)
+			}
+		})
+	}
+}
+
+func TestDeploymentConditionsStatus(t *testing.T) {
+	available := []v1beta1.DeploymentCondition{
+		{Type: v1beta1.DeploymentAvailable, Status: api.ConditionTrue},
+	}
+	cases := []struct {
+		Name               string
+		ObservedGeneration int64
+		Conditions         []v1beta1.DeploymentCondition
+		Done               bool
+		ExpectError        bool
+	}{
+		{"available", 2, []v1beta1.DeploymentCondition{
+			{Type: v1beta1.DeploymentAvailable, Status: api.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+			{Type: v1beta1.DeploymentProgressing, Status: api.ConditionTrue, Reason: "ReplicaSetUpdated"},
+		}, true, false},
+		{"not available", 2, []v1beta1.DeploymentCondition{
+			{Type: v1beta1.DeploymentAvailable, Status: api.ConditionFalse, Reason: "MinimumReplicasUnavailable"},
+		}, false, false},
+		{"not reported yet", 2, nil, false, false},
+		{"not observed yet", 1, []v1beta1.DeploymentCondition{
+			{Type: v1beta1.DeploymentAvailable, Status: api.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+		}, false, false},
+		{"deadline exceeded", 2, []v1beta1.DeploymentCondition{
+			{Type: v1beta1.DeploymentAvailable, Status: api.ConditionFalse, Reason: "MinimumReplicasUnavailable"},
+			{Type: v1beta1.DeploymentProgressing, Status: api.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		}, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deployment := &v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "web", Generation: 2},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: tc.ObservedGeneration,
+					Conditions:         tc.Conditions,
+				},
+			}
+			done, waiting, err := deploymentConditionsStatus(available)(deployment)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != tc.Done {
+				t.Fatalf("Expected done to be %t (%s)", tc.Done, waiting)
+			}
+		})
+	}
+}
+
+func TestMigrateStateSelectorMapToBlock(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "default/test",
+		Attributes: map[string]string{
+			"spec.#":                       "1",
+			"spec.0.replicas":              "1",
+			"spec.0.selector.%":            "2",
+			"spec.0.selector.app":          "web",
+			"spec.0.selector.tier":         "frontend",
+			"spec.0.template.0.spec.#":     "1",
+			"spec.0.template.0.metadata.#": "1",
+		},
+	}
+
+	is, err := migrateStateSelectorMapToBlock(is)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"spec.#":                                "1",
+		"spec.0.replicas":                       "1",
+		"spec.0.selector.#":                     "1",
+		"spec.0.selector.0.match_expressions.#": "0",
+		"spec.0.selector.0.match_labels.%":      "2",
+		"spec.0.selector.0.match_labels.app":    "web",
+		"spec.0.selector.0.match_labels.tier":   "frontend",
+		"spec.0.template.0.spec.#":              "1",
+		"spec.0.template.0.metadata.#":          "1",
+	}
+	if !reflect.DeepEqual(is.Attributes, expected) {
+		t.Fatalf("Unexpected attributes after migration.\nExpected: %#v\nGiven:    %#v", expected, is.Attributes)
+	}
+}
+
+func testAccCheckDeploymentUID(old, new *v1beta1.Deployment, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if same && old.UID != new.UID {
+			return fmt.Errorf("Expected deployment to be updated in place, but it was recreated (%s != %s)", old.UID, new.UID)
+		}
+		if !same && old.UID == new.UID {
+			return fmt.Errorf("Expected deployment to be recreated, but it kept UID %s", old.UID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDeploymentTemplateChecksum(d *v1beta1.Deployment, annotation string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		checksum, ok := d.Spec.Template.Annotations[annotation]
+		if !ok {
+			return fmt.Errorf("Expected the pod template to have the %q annotation", annotation)
+		}
+		return resource.TestCheckResourceAttr("kubernetes_deployment.test", "template_checksums."+annotation, checksum)(s)
+	}
+}
+
+func testAccPutConfigMap(t *testing.T, name string, data map[string]string) func() {
+	return func() {
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		cfgMap := &api.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       data,
+		}
+		_, err := conn.CoreV1().ConfigMaps("default").Update(cfgMap)
+		if errors.IsNotFound(err) {
+			_, err = conn.CoreV1().ConfigMaps("default").Create(cfgMap)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testAccCheckDeploymentRestartedAt(d *v1beta1.Deployment, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		restartedAt := d.Spec.Template.Annotations[restartedAtAnnotation]
+		if restartedAt != expected {
+			return fmt.Errorf("Expected the pod template to be restarted at %q, got %q", expected, restartedAt)
+		}
+		return nil
+	}
+}
+
+func testAccSetDeploymentImage(t *testing.T, name, image string) func() {
+	return func() {
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		ops := PatchOperations{
+			&ReplaceOperation{
+				Path:  "/spec/template/spec/containers/0/image",
+				Value: image,
+			},
+		}
+		data, err := ops.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = conn.ExtensionsV1beta1().Deployments("default").Patch(name, pkgApi.JSONPatchType, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testAccCheckReplicaSetsCount(app string, expected int) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+	rsList, err := conn.ExtensionsV1beta1().ReplicaSets("default").List(meta_v1.ListOptions{
+		LabelSelector: "app=" + app,
+	})
+	if err != nil {
+		return err
+	}
+	if len(rsList.Items) != expected {
+		return fmt.Errorf("Expected %d replica sets of %s, found %d", expected, app, len(rsList.Items))
+	}
+	return nil
+}
+
+func testAccDeleteReplicaSets(app string) {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+	policy := meta_v1.DeletePropagationBackground
+	conn.ExtensionsV1beta1().ReplicaSets("default").DeleteCollection(&meta_v1.DeleteOptions{
+		PropagationPolicy: &policy,
+	}, meta_v1.ListOptions{LabelSelector: "app=" + app})
+}
+
+func testAccDeleteConfigMap(name string) {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+	conn.CoreV1().ConfigMaps("default").Delete(name, &meta_v1.DeleteOptions{})
+}
+
+func pause() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		time.Sleep(1 * time.Minute)
+		return nil
+	}
+}
+
+func testAccCheckKubernetesDeploymentDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_deployment" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Name == rs.Primary.ID {
+				return fmt.Errorf("Deployment still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesDeploymentExists(n string, obj *v1beta1.Deployment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesDeploymentConfig_minimal(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+		replicas = 20
+    selector {
+      match_labels {
+        foo = "bar"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					foo = "bar"
+				}
+			}
+			spec {
+				container {
+					image = "nginx:1.7.8"
+					name  = "tf-acc-test"
+				}
+			}
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDeploymentConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    annotations {
+      TestAnnotationOne = "one"
+      TestAnnotationTwo = "two"
+    }
+    labels {
+      TestLabelOne = "one"
+      TestLabelTwo = "two"
+      TestLabelThree = "three"
+    }
+    name = "%s"
+  }
+  spec {
+    replicas = 100 # This is intentionally high to exercise the waiter
+    selector {
+      match_labels {
+        TestLabelOne = "one"
+        TestLabelTwo = "two"
+        TestLabelThree = "three"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					TestLabelOne = "one"
+					TestLabelTwo = "two"
+					TestLabelThree = "three"
+				}
+			}
+			spec {
+				container {
+					image = "nginx:1.7.8"
+					name  = "tf-acc-test"
+				}
+			}
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDeploymentConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    annotations {
+      TestAnnotationOne = "one"
+      Different = "1234"
+    }
+    labels {
+      TestLabelOne = "one"
+      TestLabelThree = "three"
+    }
+    name = "%s"
+  }
+  spec {
+		paused = true
+		progress_deadline_seconds = 30
+		revision_history_limit = 4
+    selector {
+      match_labels {
+        TestLabelOne = "one"
+        TestLabelTwo = "two"
+        TestLabelThree = "three"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					TestLabelOne = "one"
+					TestLabelTwo = "two"
+					TestLabelThree = "three"
+				}
+			}
+			spec {
+				container {
+					image = "nginx:1.7.9"
+					name  = "tf-acc-test"
+				}
+			}
+    }
+  }
+}`, name)
+}
+
+func testAccKubernetesDeploymentConfigWithTemplateMetadata(depName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+    labels {
+      Test = "TfAcceptanceTest"
+    }
+  }
+
+  spec {
+    selector {
+      match_labels {
+        Test = "TfAcceptanceTest"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					foo = "bar"
+					Test = "TfAcceptanceTest"
+				}
+				annotations {
+					"prometheus.io/scrape" = "true"
+					"prometheus.io/scheme" = "https"
+					"prometheus.io/port"   = "4000"
+				}
+			}
+			spec {
+				container {
+					image = "%s"
+					name  = "containername"
+				}
+			}
+    }
+  }
+}
+`, depName, imageName)
+}
+
+func testAccKubernetesDeploymentConfigWithTemplateMetadataModified(depName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+    labels {
+      Test = "TfAcceptanceTest"
+    }
+  }
+
+  spec {
+    selector {
+      match_labels {
+        Test = "TfAcceptanceTest"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					foo = "bar"
+					Test = "TfAcceptanceTest"
+				}
+				annotations {
+					"prometheus.io/scrape" = "true"
+					"prometheus.io/scheme" = "http"
+					"prometheus.io/port"   = "8080"
+				}
+			}
+			spec {
+				container {
+					image = "%s"
+					name  = "containername"
+				}
+			}
+    }
+  }
+}
+`, depName, imageName)
+}
+
+func testAccKubernetesDeploymentConfig_rollback(name, imageName string, replicas int, revision string) string {
+	rollback := ""
+	if revision != "" {
+		rollback = fmt.Sprintf(`
+    rollback_to {
+      revision = %s
+    }`, revision)
+	}
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    replicas = %d
+    %s
+    selector {
+      match_labels {
+        foo = "bar"
+      }
+    }
+    template {
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, name, replicas, rollback, imageName)
+}
+
+func testAccKubernetesDeploymentWithInitContainer(depName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+    labels {
+			foo = "bar"
+    }
+  }
+
+  spec {
+    selector {
+      match_labels {
+        foo = "bar"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					foo = "bar"
+				}
+			}
+			spec {
+				init_container {
+					name = "hello"
+					image = "alpine"
+					command = ["echo", "'hello'"]
+				}
+				container {
+					image = "%s"
+					name  = "containername"
+				}
+			}
+    }
+  }
+}
+`, depName, imageName)
+}
+
+func testAccKubernetesDeploymentWithOptionalConfigMapVolume(depName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    selector {
+      match_labels {
+        foo = "bar"
+      }
+    }
+    template {
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "containername"
+          volume_mount {
+            mount_path = "/etc/app"
+            name       = "cfg"
+          }
+        }
+        volume {
+          name = "cfg"
+          config_map {
+            name     = "%s-missing"
+            optional = true
+
+            items {
+              key  = "log.level"
+              path = "log/level"
+            }
+
+            items {
+              key  = "app.yaml"
+              path = "app.yaml"
+              mode = "0400"
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`, depName, imageName, depName)
+}
+
+func testAccKubernetesDeploymentWithNoTopLevelLabels(depName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    selector {
+      match_labels {
+        foo = "bar"
+      }
+    }
+    template {
+			metadata {
+				labels {
+					foo = "bar"
+				}
+			}
+			spec {
+				container {
+					image = "%s"
+					name  = "containername"
+				}
+			}
+    }
+  }
+}
+`, depName, imageName)
+}
+
+func testAccKubernetesDeploymentConfig_generatedSelector(depName, app, tier string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    template {
+      metadata {
+        labels {
+          app  = "%s"
+          tier = "%s"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, app, tier)
+}
+
+func testAccKubernetesDeploymentConfig_matchExpressions(depName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    selector {
+      match_labels {
+        app = "web"
+      }
+      match_expressions {
+        key      = "tier"
+        operator = "In"
+        values   = ["frontend", "backend"]
+      }
+    }
+    template {
+      metadata {
+        labels {
+          app  = "web"
+          tier = "frontend"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName)
+}
+
+func testAccKubernetesDeploymentConfig_templateAnnotationsFrom(depName, cfgMapName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  template_annotations_from {
+    kind = "ConfigMap"
+    name = "%s"
+  }
+
+  spec {
+    template {
+      metadata {
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, cfgMapName)
+}
+
+func testAccKubernetesDeploymentConfig_ignoreContainerImages(depName string, replicas int) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  ignore_container_images = ["containername"]
+
+  spec {
+    replicas = %d
+    template {
+      metadata {
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, replicas)
+}
+
+func testAccKubernetesDeploymentConfig_progressDeadline(depName, image string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    progress_deadline_seconds = 15
+    template {
+      metadata {
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, image)
+}
+
+func testAccKubernetesDeploymentConfig_restartedAt(depName, restartedAt string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  restarted_at = "%s"
+
+  spec {
+    template {
+      metadata {
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, restartedAt)
+}
+
+func testAccKubernetesDeploymentConfig_templateAnnotationsFromAnnotation(depName, cfgMapName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  template_annotations_from {
+    kind       = "ConfigMap"
+    name       = "%s"
+    annotation = "checksum/config"
+  }
+
+  spec {
+    template {
+      metadata {
+        annotations {
+          description = "web"
+        }
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, cfgMapName)
+}
 
-	return nil
+func testAccKubernetesDeploymentConfig_configChecksumAnnotations(depName, cfgMapName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  config_checksum_annotations {
+    "checksum/config" = "ConfigMap/%s"
+  }
+
+  spec {
+    template {
+      metadata {
+        annotations {
+          description = "web"
+        }
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, cfgMapName)
 }
 
-func testAccCheckKubernetesDeploymentExists(n string, obj *v1beta1.Deployment) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[n]
-		if !ok {
-			return fmt.Errorf("Not found: %s", n)
-		}
+func testAccKubernetesDeploymentConfig_paused(depName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+  spec {
+    paused = true
 
-		namespace, name, err := idParts(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
+    template {
+      metadata {
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, imageName)
+}
 
-		out, err := conn.ExtensionsV1beta1().Deployments(namespace).Get(name, meta_v1.GetOptions{})
-		if err != nil {
-			return err
-		}
+func testAccKubernetesDeploymentConfig_pausable(depName, imageName string, paused bool, updateTimeout string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
 
-		*obj = *out
-		return nil
+  spec {
+    replicas = 2
+    paused   = %t
+
+    template {
+      metadata {
+        labels {
+          app = "web"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "containername"
+        }
+      }
+    }
+  }
+
+  timeouts {
+    update = "%s"
+  }
+}
+`, depName, paused, imageName, updateTimeout)
+}
+
+func testAccKubernetesDeploymentConfig_deletePropagation(depName, policy string, cleanupDelay int) string {
+	cleanup := ""
+	if cleanupDelay > 0 {
+		cleanup = fmt.Sprintf("orphan_cleanup_delay_seconds = %d", cleanupDelay)
 	}
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  delete_propagation = "%s"
+  %s
+
+  spec {
+    template {
+      metadata {
+        labels {
+          app = "%s"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+        }
+      }
+    }
+  }
+}
+`, depName, policy, cleanup, depName)
 }
 
-func testAccKubernetesDeploymentConfig_minimal(name string) string {
+func testAccKubernetesDeploymentConfig_waitForRollout(name string, wait bool) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
   metadata {
     name = "%s"
   }
   spec {
-		replicas = 20
+    replicas         = 2
+    wait_for_rollout = %t
     selector {
-      foo = "bar"
+      match_labels {
+        foo = "bar"
+      }
     }
     template {
-			metadata {
-				labels {
-					foo = "bar"
-				}
-			}
-			spec {
-				container {
-					image = "nginx:1.7.8"
-					name  = "tf-acc-test"
-				}
-			}
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:tf-acc-test-missing"
+          name  = "tf-acc-test"
+        }
+      }
     }
   }
+  timeouts {
+    create = "30s"
+  }
 }
-`, name)
+`, name, wait)
 }
 
-func testAccKubernetesDeploymentConfig_basic(name string) string {
+func testAccKubernetesDeploymentConfig_affinity(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
   metadata {
-    annotations {
-      TestAnnotationOne = "one"
-      TestAnnotationTwo = "two"
-    }
-    labels {
-      TestLabelOne = "one"
-      TestLabelTwo = "two"
-      TestLabelThree = "three"
-    }
     name = "%s"
   }
   spec {
-    replicas = 100 # This is intentionally high to exercise the waiter
+    replicas = 2
     selector {
-      TestLabelOne = "one"
-      TestLabelTwo = "two"
-      TestLabelThree = "three"
+      match_labels {
+        foo = "bar"
+      }
     }
     template {
-			metadata {
-				labels {
-					TestLabelOne = "one"
-					TestLabelTwo = "two"
-					TestLabelThree = "three"
-				}
-			}
-			spec {
-				container {
-					image = "nginx:1.7.8"
-					name  = "tf-acc-test"
-				}
-			}
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        affinity {
+          node_affinity {
+            required_during_scheduling_ignored_during_execution {
+              node_selector_term {
+                match_expressions {
+                  key      = "beta.kubernetes.io/os"
+                  operator = "In"
+                  values   = ["linux"]
+                }
+              }
+            }
+          }
+          pod_anti_affinity {
+            preferred_during_scheduling_ignored_during_execution {
+              weight = 100
+              pod_affinity_term {
+                label_selector {
+                  match_labels {
+                    foo = "bar"
+                  }
+                }
+                topology_key = "kubernetes.io/hostname"
+              }
+            }
+            preferred_during_scheduling_ignored_during_execution {
+              weight = 50
+              pod_affinity_term {
+                label_selector {
+                  match_labels {
+                    foo = "bar"
+                  }
+                }
+                topology_key = "failure-domain.beta.kubernetes.io/zone"
+              }
+            }
+          }
+        }
+        container {
+          image = "nginx:1.7.8"
+          name  = "tf-acc-test"
+        }
+      }
     }
   }
 }
 `, name)
 }
 
-func testAccKubernetesDeploymentConfig_modified(name string) string {
+func testAccKubernetesDeploymentConfig_quotaExceeded(name string) string {
 	return fmt.Sprintf(`
-resource "kubernetes_deployment" "test" {
+resource "kubernetes_namespace" "test" {
   metadata {
-    annotations {
-      TestAnnotationOne = "one"
-      Different = "1234"
-    }
-    labels {
-      TestLabelOne = "one"
-      TestLabelThree = "three"
-    }
     name = "%s"
   }
+}
+
+resource "kubernetes_resource_quota" "test" {
+  metadata {
+    name      = "tiny"
+    namespace = "${kubernetes_namespace.test.metadata.0.name}"
+  }
   spec {
-		paused = true
-		progress_deadline_seconds = 30
-		revision_history_limit = 4
+    hard {
+      pods = 1
+    }
+  }
+}
+
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name      = "%s"
+    namespace = "${kubernetes_resource_quota.test.metadata.0.namespace}"
+  }
+  spec {
+    replicas = 2
     selector {
-      TestLabelOne = "one"
-      TestLabelTwo = "two"
-      TestLabelThree = "three"
+      match_labels {
+        foo = "bar"
+      }
     }
     template {
-			metadata {
-				labels {
-					TestLabelOne = "one"
-					TestLabelTwo = "two"
-					TestLabelThree = "three"
-				}
-			}
-			spec {
-				container {
-					image = "nginx:1.7.9"
-					name  = "tf-acc-test"
-				}
-			}
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "tf-acc-test"
+        }
+      }
     }
   }
-}`, name)
+  timeouts {
+    create = "5m"
+  }
+}
+`, name, name)
 }
 
-func testAccKubernetesDeploymentConfigWithTemplateMetadata(depName, imageName string) string {
+func testAccKubernetesDeploymentConfig_skipDrainOnDelete(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
   metadata {
     name = "%s"
-    labels {
-      Test = "TfAcceptanceTest"
-    }
   }
-
+  delete_propagation   = "Background"
+  skip_drain_on_delete = true
   spec {
+    replicas = 2
     selector {
-      Test = "TfAcceptanceTest"
-		}
+      match_labels {
+        foo = "bar"
+      }
+    }
     template {
-			metadata {
-				labels {
-					foo = "bar"
-					Test = "TfAcceptanceTest"
-				}
-				annotations {
-					"prometheus.io/scrape" = "true"
-					"prometheus.io/scheme" = "https"
-					"prometheus.io/port"   = "4000"
-				}
-			}
-			spec {
-				container {
-					image = "%s"
-					name  = "containername"
-				}
-			}
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image   = "busybox"
+          name    = "tf-acc-test"
+          command = ["sleep", "3600"]
+        }
+        termination_grace_period_seconds = 120
+      }
     }
   }
 }
-`, depName, imageName)
+`, name)
 }
 
-func testAccKubernetesDeploymentConfigWithTemplateMetadataModified(depName, imageName string) string {
+func testAccKubernetesDeploymentConfig_revisionHistoryLimitZero(name, image string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
   metadata {
     name = "%s"
-    labels {
-      Test = "TfAcceptanceTest"
-    }
   }
 
   spec {
-    selector {
-      Test = "TfAcceptanceTest"
-		}
+    revision_history_limit = 0
     template {
-			metadata {
-				labels {
-					foo = "bar"
-					Test = "TfAcceptanceTest"
-				}
-				annotations {
-					"prometheus.io/scrape" = "true"
-					"prometheus.io/scheme" = "http"
-					"prometheus.io/port"   = "8080"
-				}
-			}
-			spec {
-				container {
-					image = "%s"
-					name  = "containername"
-				}
-			}
+      metadata {
+        labels {
+          app = "%s"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "containername"
+        }
+      }
     }
   }
 }
-`, depName, imageName)
+`, name, name, image)
 }
 
-func testAccKubernetesDeploymentWithInitContainer(depName, imageName string) string {
+func testAccKubernetesDeploymentConfig_waitForAvailable(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
   metadata {
     name = "%s"
-    labels {
-			foo = "bar"
-    }
+  }
+
+  wait_for {
+    condition = "Available"
   }
 
   spec {
-    selector {
-			foo = "bar"
-		}
+    replicas         = 2
+    wait_for_rollout = false
+
     template {
-			metadata {
-				labels {
-					foo = "bar"
-				}
-			}
-			spec {
-				init_container {
-					name = "hello"
-					image = "alpine"
-					command = ["echo", "'hello'"]
-				}
-				container {
-					image = "%s"
-					name  = "containername"
-				}
-			}
+      metadata {
+        labels {
+          app = "%s"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.9"
+          name  = "containername"
+        }
+      }
     }
   }
 }
-`, depName, imageName)
+`, name, name)
 }
 
-func testAccKubernetesDeploymentWithNoTopLevelLabels(depName, imageName string) string {
+func testAccKubernetesDeploymentConfig_serverDefaults(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
   metadata {
@@ -512,23 +2485,38 @@ resource "kubernetes_deployment" "test" {
   }
 
   spec {
-    selector {
-			foo = "bar"
-		}
     template {
-			metadata {
-				labels {
-					foo = "bar"
-				}
-			}
-			spec {
-				container {
-					image = "%s"
-					name  = "containername"
-				}
-			}
+      metadata {
+        labels {
+          app = "%s"
+        }
+      }
+      spec {
+        host_network = true
+        dns_policy   = "ClusterFirstWithHostNet"
+
+        container {
+          image = "nginx:1.7.8"
+          name  = "containername"
+
+          port {
+            container_port = 8080
+          }
+
+          volume_mount {
+            name       = "podinfo"
+            mount_path = "/etc/podinfo"
+          }
+        }
+
+        volume {
+          name = "podinfo"
+
+          downward_api {}
+        }
+      }
     }
   }
 }
-`, depName, imageName)
+`, name, name)
 }
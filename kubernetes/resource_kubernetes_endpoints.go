@@ -0,0 +1,221 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func resourceKubernetesEndpoints() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesEndpointsCreate,
+		Read:   resourceKubernetesEndpointsRead,
+		Exists: resourceKubernetesEndpointsExists,
+		Update: resourceKubernetesEndpointsUpdate,
+		Delete: resourceKubernetesEndpointsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("endpoints", false),
+			"subset": {
+				Type:        schema.TypeSet,
+				Description: "Set of addresses and ports that comprise a service. More info: https://kubernetes.io/docs/concepts/services-networking/service/#services-without-selectors",
+				Optional:    true,
+				Elem:        endpointSubsetResource(),
+			},
+		},
+	}
+}
+
+func endpointSubsetResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:        schema.TypeSet,
+				Description: "IP addresses which offer the related ports and are ready to accept traffic.",
+				Optional:    true,
+				Elem:        endpointAddressResource(),
+			},
+			"not_ready_address": {
+				Type:        schema.TypeSet,
+				Description: "IP addresses which offer the related ports but are not currently marked as ready because they have not yet finished starting, have recently failed a readiness check, or have recently failed a liveness check.",
+				Optional:    true,
+				Elem:        endpointAddressResource(),
+			},
+			"port": {
+				Type:        schema.TypeSet,
+				Description: "Port numbers available on the related IP addresses.",
+				Optional:    true,
+				Elem:        endpointPortResource(),
+			},
+		},
+	}
+}
+
+func endpointPortResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of this port within the endpoint. Must match the name of the port in the service. Optional if only one port is defined.",
+				Optional:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "The port that will be exposed by this endpoint.",
+				Required:     true,
+				ValidateFunc: validatePortNum,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Description:  "The IP protocol for this port. Supports `TCP` and `UDP`. Default is `TCP`.",
+				Optional:     true,
+				Default:      "TCP",
+				ValidateFunc: validateAttributeValueIsIn([]string{"TCP", "UDP"}),
+			},
+		},
+	}
+}
+
+func endpointAddressResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Type:         schema.TypeString,
+				Description:  "The IP of this endpoint. May not be loopback (127.0.0.0/8), link-local (169.254.0.0/16), or link-local multicast (224.0.0.0/24).",
+				Required:     true,
+				ValidateFunc: validateIPAddress,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The hostname of this endpoint.",
+				Optional:    true,
+			},
+			"node_name": {
+				Type:        schema.TypeString,
+				Description: "Node hosting this endpoint. This can be used to determine endpoints local to a node.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesEndpointsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	ep := api.Endpoints{
+		ObjectMeta: metadata,
+		Subsets:    expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
+	}
+	log.Printf("[INFO] Creating new endpoints: %#v", ep)
+	out, err := conn.CoreV1().Endpoints(metadata.Namespace).Create(&ep)
+	if err != nil {
+		return fmt.Errorf("Failed to create endpoints: %s", err)
+	}
+	log.Printf("[INFO] Submitted new endpoints: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEndpointsRead(d, meta)
+}
+
+func resourceKubernetesEndpointsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Reading endpoints %s", name)
+	ep, err := conn.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received endpoints: %#v", ep)
+	err = d.Set("metadata", flattenMetadata(ep.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+	err = d.Set("subset", flattenEndpointsSubsets(ep.Subsets))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesEndpointsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("subset") {
+		ops = append(ops, &AddOperation{
+			Path:  "/subsets",
+			Value: expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating endpoints %q: %v", name, string(data))
+	out, err := conn.CoreV1().Endpoints(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update endpoints: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated endpoints: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEndpointsRead(d, meta)
+}
+
+func resourceKubernetesEndpointsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Deleting endpoints: %#v", name)
+	err = conn.CoreV1().Endpoints(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	log.Printf("[INFO] Endpoints %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesEndpointsExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking endpoints %s", name)
+	_, err = conn.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func TestAccKubernetesEndpoints_basic(t *testing.T) {
+	var conf api.Endpoints
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_endpoints.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesEndpointsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEndpointsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesEndpointsExists("kubernetes_endpoints.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "subset.#", "1"),
+					testAccCheckEndpointsSubsets(&conf, []int{1}, []int{0}),
+				),
+			},
+			{
+				Config: testAccKubernetesEndpointsConfig_mixedReadiness(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesEndpointsExists("kubernetes_endpoints.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "subset.#", "2"),
+					testAccCheckEndpointsSubsets(&conf, []int{1, 2}, []int{1, 0}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesEndpoints_importBasic(t *testing.T) {
+	resourceName := "kubernetes_endpoints.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesEndpointsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEndpointsConfig_mixedReadiness(name),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+// testAccCheckEndpointsSubsets checks the number of ready and not ready
+// addresses of each subset, in any order, to make sure they weren't merged.
+func testAccCheckEndpointsSubsets(ep *api.Endpoints, ready, notReady []int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(ep.Subsets) != len(ready) {
+			return fmt.Errorf("Expected %d subsets, got %d: %#v", len(ready), len(ep.Subsets), ep.Subsets)
+		}
+		matched := make([]bool, len(ready))
+		for _, subset := range ep.Subsets {
+			for i := range ready {
+				if !matched[i] && len(subset.Addresses) == ready[i] && len(subset.NotReadyAddresses) == notReady[i] {
+					matched[i] = true
+					break
+				}
+			}
+		}
+		for i, ok := range matched {
+			if !ok {
+				return fmt.Errorf("No subset with %d ready and %d not ready addresses: %#v", ready[i], notReady[i], ep.Subsets)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesEndpointsDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_endpoints" {
+			continue
+		}
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		resp, err := conn.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Endpoints still exist: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesEndpointsExists(n string, obj *api.Endpoints) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		out, err := conn.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesEndpointsConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_endpoints" "test" {
+	metadata {
+		name = "%s"
+	}
+	subset {
+		address {
+			ip = "10.0.0.4"
+		}
+		port {
+			name = "http"
+			port = 80
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesEndpointsConfig_mixedReadiness(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_endpoints" "test" {
+	metadata {
+		name = "%s"
+	}
+	subset {
+		address {
+			ip       = "10.0.0.4"
+			hostname = "backend-1"
+		}
+		not_ready_address {
+			ip = "10.0.0.5"
+		}
+		port {
+			name = "http"
+			port = 80
+		}
+	}
+	subset {
+		address {
+			ip = "10.0.1.4"
+		}
+		address {
+			ip = "10.0.1.5"
+		}
+		port {
+			name     = "dns"
+			port     = 53
+			protocol = "UDP"
+		}
+	}
+}`, name)
+}
@@ -8,7 +8,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/apis/autoscaling/v1"
 )
 
@@ -82,7 +81,7 @@ func resourceKubernetesHorizontalPodAutoscaler() *schema.Resource {
 }
 
 func resourceKubernetesHorizontalPodAutoscalerCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	svc := api.HorizontalPodAutoscaler{
@@ -102,7 +101,7 @@ func resourceKubernetesHorizontalPodAutoscalerCreate(d *schema.ResourceData, met
 }
 
 func resourceKubernetesHorizontalPodAutoscalerRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -131,7 +130,7 @@ func resourceKubernetesHorizontalPodAutoscalerRead(d *schema.ResourceData, meta
 }
 
 func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -159,7 +158,7 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, met
 }
 
 func resourceKubernetesHorizontalPodAutoscalerDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -167,7 +166,7 @@ func resourceKubernetesHorizontalPodAutoscalerDelete(d *schema.ResourceData, met
 	}
 	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
 	err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Delete(name, &meta_v1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -178,7 +177,7 @@ func resourceKubernetesHorizontalPodAutoscalerDelete(d *schema.ResourceData, met
 }
 
 func resourceKubernetesHorizontalPodAutoscalerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 	kubernetes "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
 	batchv1 "k8s.io/client-go/pkg/apis/batch/v1"
 )
 
@@ -24,8 +25,18 @@ func resourceKubernetesJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceKubernetesJobCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("job", true),
+			"exit_code": {
+				Type:        schema.TypeMap,
+				Description: "Exit code of each container of the job's most recent terminated pod, by container name. Empty until a container terminated, or once the pods are removed.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the job owned by the cluster",
@@ -45,8 +56,23 @@ func resourceKubernetesJob() *schema.Resource {
 	return s
 }
 
+// resourceKubernetesJobCustomizeDiff runs the plan-time checks of the pod
+// template, and checks that a selector is only set along with manual_selector.
+func resourceKubernetesJobCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	err := podSpecCustomizeDiff("spec.0.template.0.")(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if len(d.Get("spec.0.selector").([]interface{})) > 0 && !d.Get("spec.0.manual_selector").(bool) {
+		return fmt.Errorf("spec.0.selector requires spec.0.manual_selector to be true, " +
+			"otherwise Kubernetes generates the selector itself")
+	}
+	return nil
+}
+
 func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	spec, err := expandJobSpec(d.Get("spec").([]interface{}))
@@ -54,6 +80,10 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 	spec.Template.ObjectMeta.Annotations = metadata.Annotations
+	if spec.ManualSelector != nil && *spec.ManualSelector {
+		// The selector has to match the pods, which have no labels of their own
+		spec.Template.ObjectMeta.Labels = metadata.Labels
+	}
 
 	job := batchv1.Job{
 		ObjectMeta: metadata,
@@ -70,11 +100,19 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	if d.Get("spec.0.wait_for_completion").(bool) {
+		log.Printf("[DEBUG] Waiting for job %s to complete", d.Id())
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate), waitForJobCompletionFunc(conn, out.Namespace, out.Name))
+		if err != nil {
+			return jobWaitError(conn, out, err)
+		}
+	}
+
 	return resourceKubernetesJobRead(d, meta)
 }
 
 func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -131,7 +169,7 @@ func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -146,6 +184,19 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	log.Printf("[INFO] Received job: %#v", job)
 
+	selector := metav1.FormatLabelSelector(job.Spec.Selector)
+	log.Printf("[INFO] Listing pods of job %s matching %q", name, selector)
+	pods, err := conn.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		// Only used for diagnostics, so don't fail the refresh, e.g. when not allowed to list pods
+		log.Printf("[WARN] Failed to list pods of job %s, keeping its exit codes: %s", name, err)
+	} else {
+		err = d.Set("exit_code", flattenJobExitCodes(pods.Items))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Remove server-generated labels unless using manual selector
 	if _, ok := d.GetOk("spec.0.manual_selector"); !ok {
 		labels := job.ObjectMeta.Labels
@@ -157,12 +208,6 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 		if _, ok := labels["job-name"]; ok {
 			delete(labels, "job-name")
 		}
-
-		labels = job.Spec.Selector.MatchLabels
-
-		if _, ok := labels["controller-uid"]; ok {
-			delete(labels, "controller-uid")
-		}
 	}
 
 	err = d.Set("metadata", flattenMetadata(job.ObjectMeta, d))
@@ -170,7 +215,7 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	jobSpec, err := flattenJobSpec(job.Spec)
+	jobSpec, err := flattenJobSpec(job.Spec, d)
 	if err != nil {
 		return err
 	}
@@ -183,8 +228,62 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// jobCompletionStatus tells whether the job finished successfully. Work-queue
+// jobs have no completion count: they're done once any pod succeeded and the
+// remaining workers exited.
+func jobCompletionStatus(job *batchv1.Job) (bool, error) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != api.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("Job %q failed: %s: %s", job.GetName(), c.Reason, c.Message)
+		}
+	}
+
+	if job.Spec.Completions == nil {
+		return job.Status.Succeeded >= 1 && job.Status.Active == 0, nil
+	}
+	return job.Status.Succeeded >= *job.Spec.Completions, nil
+}
+
+func waitForJobCompletionFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return func() *resource.RetryError {
+		job, err := conn.BatchV1().Jobs(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		done, err := jobCompletionStatus(job)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if done {
+			return nil
+		}
+		return resource.RetryableError(fmt.Errorf("Waiting for job %q to complete (%d active, %d succeeded, %d failed pods)",
+			name, job.Status.Active, job.Status.Succeeded, job.Status.Failed))
+	}
+}
+
+// jobWaitError appends why the pods of the job aren't scheduled or keep
+// failing to an error of the completion wait.
+func jobWaitError(conn *kubernetes.Clientset, job *batchv1.Job, err error) error {
+	unschedulable, dErr := unschedulablePodsDiagnostics(conn, job.Namespace, job.Spec.Selector, 3)
+	if dErr != nil {
+		log.Printf("[DEBUG] Failed to gather scheduling failures of job %s: %s", job.Name, dErr)
+	}
+	failing, dErr := failingPodsDiagnostics(conn, job.Namespace, job.Spec.Selector, 3)
+	if dErr != nil {
+		log.Printf("[DEBUG] Failed to gather container failures of job %s: %s", job.Name, dErr)
+	}
+	return fmt.Errorf("%s%s%s", err, unschedulable, failing)
+}
+
 func resourceKubernetesJobDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -193,7 +292,7 @@ func resourceKubernetesJobDelete(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Deleting job: %#v", name)
 	err = conn.BatchV1().Jobs(namespace).Delete(name, nil)
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -220,7 +319,7 @@ func resourceKubernetesJobDelete(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceKubernetesJobExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
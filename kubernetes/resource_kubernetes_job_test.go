@@ -2,13 +2,15 @@ package kubernetes
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
 	api "k8s.io/client-go/pkg/apis/batch/v1"
 )
 
@@ -34,6 +36,8 @@ func TestAccKubernetesJob_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.#", "1"),
 					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.parallelism", "2"),
 					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.template.0.container.0.name", "hello"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.manual_selector", "false"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.selector.#", "0"),
 				),
 			},
 			{
@@ -54,8 +58,220 @@ func TestAccKubernetesJob_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesJob_waitForCompletion(t *testing.T) {
+	var conf api.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_job.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_waitForCompletion(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.wait_for_completion", "true"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "exit_code.true", "0"),
+					testAccCheckJobComplete(&conf),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_manualSelector(t *testing.T) {
+	var conf api.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_job.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_manualSelector(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "metadata.0.labels.job", name),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.manual_selector", "true"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.selector.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.selector.0.match_labels.job", name),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_workQueue(t *testing.T) {
+	var conf api.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_job.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_workQueue(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.parallelism", "3"),
+					resource.TestCheckNoResourceAttr("kubernetes_job.test", "spec.0.completions"),
+					testAccCheckJobCompletions(&conf, nil),
+				),
+			},
+		},
+	})
+}
+
+func TestJobCompletionStatus(t *testing.T) {
+	job := func(completions *int32, status api.JobStatus) *api.Job {
+		return &api.Job{
+			Spec:   api.JobSpec{Completions: completions},
+			Status: status,
+		}
+	}
+
+	cases := []struct {
+		Name string
+		Job  *api.Job
+		Done bool
+		Err  bool
+	}{
+		{
+			"fixed completion count in progress",
+			job(ptrToInt32(3), api.JobStatus{Active: 1, Succeeded: 2}),
+			false,
+			false,
+		},
+		{
+			"fixed completion count reached",
+			job(ptrToInt32(3), api.JobStatus{Succeeded: 3}),
+			true,
+			false,
+		},
+		{
+			"work queue with workers still running",
+			job(nil, api.JobStatus{Active: 2, Succeeded: 1}),
+			false,
+			false,
+		},
+		{
+			"work queue not started",
+			job(nil, api.JobStatus{}),
+			false,
+			false,
+		},
+		{
+			"work queue drained",
+			job(nil, api.JobStatus{Succeeded: 1}),
+			true,
+			false,
+		},
+		{
+			"complete condition",
+			job(nil, api.JobStatus{Conditions: []api.JobCondition{
+				{Type: api.JobComplete, Status: v1.ConditionTrue},
+			}}),
+			true,
+			false,
+		},
+		{
+			"failed condition",
+			job(ptrToInt32(1), api.JobStatus{Failed: 6, Conditions: []api.JobCondition{
+				{Type: api.JobFailed, Status: v1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+			}}),
+			false,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			done, err := jobCompletionStatus(tc.Job)
+			if (err != nil) != tc.Err {
+				t.Fatalf("Expected error to be %t, got: %v", tc.Err, err)
+			}
+			if done != tc.Done {
+				t.Fatalf("Expected done to be %t, got %t", tc.Done, done)
+			}
+		})
+	}
+}
+
+func TestResourceKubernetesJobCustomizeDiff_selector(t *testing.T) {
+	testCases := []struct {
+		ManualSelector bool
+		ExpectError    bool
+	}{
+		{true, false},
+		{false, true},
+	}
+	for _, tc := range testCases {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "migrate"}},
+			"spec": []interface{}{map[string]interface{}{
+				"manual_selector": tc.ManualSelector,
+				"selector": []interface{}{map[string]interface{}{
+					"match_labels": map[string]interface{}{"job": "migrate"},
+				}},
+				"template": []interface{}{map[string]interface{}{
+					"container": []interface{}{map[string]interface{}{"name": "migrate", "image": "alpine"}},
+				}},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = resourceKubernetesJob().Diff(nil, terraform.NewResourceConfig(raw), &kubeProvider{})
+		if tc.ExpectError {
+			if err == nil || !strings.Contains(err.Error(), "requires spec.0.manual_selector") {
+				t.Fatalf("Expected the selector to require manual_selector, given: %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error with manual_selector: %s", err)
+		}
+	}
+}
+
+func testAccCheckJobCompletions(job *api.Job, expected *int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if expected == nil {
+			if job.Spec.Completions != nil {
+				return fmt.Errorf("Expected completions to be unset, got %d", *job.Spec.Completions)
+			}
+			return nil
+		}
+		if job.Spec.Completions == nil || *job.Spec.Completions != *expected {
+			return fmt.Errorf("Expected completions to be %d, got %v", *expected, job.Spec.Completions)
+		}
+		return nil
+	}
+}
+
+func testAccCheckJobComplete(job *api.Job) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		done, err := jobCompletionStatus(job)
+		if err != nil {
+			return err
+		}
+		if !done {
+			return fmt.Errorf("Expected job %s to be complete, status: %#v", job.Name, job.Status)
+		}
+		return nil
+	}
+}
+
 func testAccCheckKubernetesJobDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_job" {
@@ -85,7 +301,7 @@ func testAccCheckKubernetesJobExists(n string, obj *api.Job) resource.TestCheckF
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
@@ -139,3 +355,67 @@ resource "kubernetes_job" "test" {
 	}
 }`, name)
 }
+
+func testAccKubernetesJobConfig_workQueue(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		parallelism = 3
+		template {
+			container {
+				name = "worker"
+				image = "alpine"
+				command = ["sh", "-c", "sleep 5"]
+			}
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesJobConfig_waitForCompletion(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		template {
+			container {
+				name = "true"
+				image = "busybox"
+				command = ["/bin/true"]
+			}
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesJobConfig_manualSelector(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+		labels {
+			job = "%s"
+		}
+	}
+	spec {
+		manual_selector = true
+		selector {
+			match_labels {
+				job = "%s"
+			}
+		}
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "'hello'"]
+			}
+		}
+	}
+}`, name, name, name)
+}
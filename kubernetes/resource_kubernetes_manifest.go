@@ -0,0 +1,186 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesManifest() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceKubernetesManifestCreate,
+		Read:          resourceKubernetesManifestRead,
+		Exists:        resourceKubernetesManifestExists,
+		Update:        resourceKubernetesManifestUpdate,
+		Delete:        resourceKubernetesManifestDelete,
+		CustomizeDiff: resourceKubernetesManifestCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"manifest": {
+				Type:         schema.TypeString,
+				Description:  "The YAML or JSON manifest of the object to manage, for resource types this provider doesn't model. Status and fields populated by the server are ignored.",
+				Required:     true,
+				StateFunc:    normalizeManifestStateFunc,
+				ValidateFunc: validateManifest,
+			},
+		},
+	}
+}
+
+// resourceKubernetesManifestCustomizeDiff replaces the object when the
+// manifest describes another one.
+func resourceKubernetesManifestCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("manifest") {
+		return nil
+	}
+	if manifestIdentityChanged(d) {
+		return d.ForceNew("manifest")
+	}
+	return nil
+}
+
+func resourceKubernetesManifestCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	obj, err := parseManifest(d.Get("manifest").(string))
+	if err != nil {
+		return err
+	}
+	_, kind, _, name := manifestIdentity(obj)
+
+	var path string
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		// The kind may be served shortly, e.g. after its custom resource definition was created
+		path, err = manifestCollectionPath(conn, obj)
+		if err != nil {
+			log.Printf("[DEBUG] %s", err)
+			return resource.RetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal manifest: %s", err)
+	}
+	log.Printf("[INFO] Creating new %s %s: %s", kind, name, string(body))
+	out, err := conn.Discovery().RESTClient().Post().AbsPath(path).Body(body).Do().Raw()
+	if err != nil {
+		return fmt.Errorf("Failed to create %s %s: %s", kind, name, err)
+	}
+	log.Printf("[INFO] Submitted new %s %s: %s", kind, name, string(out))
+	d.SetId(path + "/" + name)
+
+	return resourceKubernetesManifestRead(d, meta)
+}
+
+func resourceKubernetesManifestRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	log.Printf("[INFO] Reading object %s", d.Id())
+	out, err := conn.Discovery().RESTClient().Get().AbsPath(d.Id()).Do().Raw()
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received object: %s", string(out))
+
+	var remote map[string]interface{}
+	if err := json.Unmarshal(out, &remote); err != nil {
+		return fmt.Errorf("Failed to parse object %s: %s", d.Id(), err)
+	}
+	submitted, err := parseManifest(d.Get("manifest").(string))
+	if err != nil {
+		return err
+	}
+	manifest, err := json.Marshal(projectManifest(remote, submitted))
+	if err != nil {
+		return err
+	}
+	d.Set("manifest", string(manifest))
+
+	return nil
+}
+
+func resourceKubernetesManifestUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	o, n := d.GetChange("manifest")
+	oldObj, err := parseManifest(o.(string))
+	if err != nil {
+		return err
+	}
+	newObj, err := parseManifest(n.(string))
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(manifestMergePatch(oldObj, newObj))
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update patch: %s", err)
+	}
+	log.Printf("[INFO] Updating object %s: %s", d.Id(), string(data))
+	out, err := conn.Discovery().RESTClient().Patch(pkgApi.MergePatchType).AbsPath(d.Id()).Body(data).Do().Raw()
+	if err != nil {
+		return fmt.Errorf("Failed to update object %s: %s", d.Id(), err)
+	}
+	log.Printf("[INFO] Submitted updated object: %s", string(out))
+
+	return resourceKubernetesManifestRead(d, meta)
+}
+
+func resourceKubernetesManifestDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	log.Printf("[INFO] Deleting object %s", d.Id())
+	err := conn.Discovery().RESTClient().Delete().AbsPath(d.Id()).Do().Error()
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to delete object %s: %s", d.Id(), err)
+	}
+
+	// Objects with finalizers linger until those are done
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := conn.Discovery().RESTClient().Get().AbsPath(d.Id()).Do().Error()
+		if err == nil {
+			return resource.RetryableError(fmt.Errorf("Object %s still exists", d.Id()))
+		}
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return resource.NonRetryableError(err)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Object %s deleted", d.Id())
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesManifestExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubeProvider).Clientset
+
+	log.Printf("[INFO] Checking object %s", d.Id())
+	err := conn.Discovery().RESTClient().Get().AbsPath(d.Id()).Do().Error()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
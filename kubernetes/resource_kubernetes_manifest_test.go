@@ -0,0 +1,155 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesManifest_configMap(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesManifestDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesManifestConfig_configMap(name, "three", "3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_manifest.test", "id", "/api/v1/namespaces/default/configmaps/"+name),
+					testAccCheckManifestConfigMapData(name, map[string]string{"three": "3"}),
+				),
+			},
+			{
+				Config: testAccKubernetesManifestConfig_configMap(name, "four", "4"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckManifestConfigMapData(name, map[string]string{"four": "4"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesManifest_customResource(t *testing.T) {
+	// Custom resource groups and names must be lower case
+	group := strings.ToLower(fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)))
+	name := strings.ToLower(fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesManifestDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesManifestConfig_customResource(group, name, "* * * * */5"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_manifest.crd", "id", "/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions/crontabs."+group),
+					resource.TestCheckResourceAttr("kubernetes_manifest.test", "id", "/apis/"+group+"/v1/namespaces/default/crontabs/"+name),
+					resource.TestMatchResourceAttr("kubernetes_manifest.test", "manifest", regexp.MustCompile(regexp.QuoteMeta("* * * * */5"))),
+				),
+			},
+			{
+				Config: testAccKubernetesManifestConfig_customResource(group, name, "* * * * */10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("kubernetes_manifest.test", "manifest", regexp.MustCompile(regexp.QuoteMeta("* * * * */10"))),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckManifestConfigMapData(name string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		conf, err := conn.CoreV1().ConfigMaps("default").Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if len(conf.Data) != len(expected) {
+			return fmt.Errorf("Expected config map data %#v, given %#v", expected, conf.Data)
+		}
+		for k, v := range expected {
+			if conf.Data[k] != v {
+				return fmt.Errorf("Expected config map data %#v, given %#v", expected, conf.Data)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesManifestDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_manifest" {
+			continue
+		}
+		err := conn.Discovery().RESTClient().Get().AbsPath(rs.Primary.ID).Do().Error()
+		if err == nil {
+			return fmt.Errorf("Object still exists: %s", rs.Primary.ID)
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesManifestConfig_configMap(name, key, value string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_manifest" "test" {
+  manifest = <<EOF
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+data:
+  %s: "%s"
+EOF
+}
+`, name, key, value)
+}
+
+func testAccKubernetesManifestConfig_customResource(group, name, schedule string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_manifest" "crd" {
+  manifest = <<EOF
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.%s
+spec:
+  group: %s
+  version: v1
+  scope: Namespaced
+  names:
+    plural: crontabs
+    singular: crontab
+    kind: CronTab
+EOF
+}
+
+resource "kubernetes_manifest" "test" {
+  manifest = <<EOF
+apiVersion: %s/v1
+kind: CronTab
+metadata:
+  name: %s
+spec:
+  cronSpec: "%s"
+  image: busybox
+EOF
+
+  depends_on = ["kubernetes_manifest.crd"]
+}
+`, group, group, group, name, schedule)
+}
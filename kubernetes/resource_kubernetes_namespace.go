@@ -10,7 +10,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -26,15 +25,30 @@ func resourceKubernetesNamespace() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("namespace", true),
+			"metadata": namespaceMetadataSchema(),
 		},
 	}
 }
 
+func namespaceMetadataSchema() *schema.Schema {
+	s := metadataSchema("namespace", true)
+	s.Elem.(*schema.Resource).Schema["finalizers"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "Must be empty before the namespace is deleted from storage. The system `kubernetes` finalizer is part of the namespace spec and isn't managed here. More info: https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/",
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+	}
+	return s
+}
+
 func resourceKubernetesNamespaceCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	if v, ok := d.GetOk("metadata.0.finalizers"); ok {
+		metadata.Finalizers = schemaSetToStringArray(v.(*schema.Set))
+	}
 	namespace := api.Namespace{
 		ObjectMeta: metadata,
 	}
@@ -50,7 +64,7 @@ func resourceKubernetesNamespaceCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	name := d.Id()
 	log.Printf("[INFO] Reading namespace %s", name)
@@ -60,7 +74,9 @@ func resourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 	log.Printf("[INFO] Received namespace: %#v", namespace)
-	err = d.Set("metadata", flattenMetadata(namespace.ObjectMeta, d))
+	metadata := flattenMetadata(namespace.ObjectMeta, d)
+	metadata[0]["finalizers"] = newStringSet(schema.HashString, namespace.Finalizers)
+	err = d.Set("metadata", metadata)
 	if err != nil {
 		return err
 	}
@@ -69,9 +85,16 @@ func resourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesNamespaceUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("metadata.0.finalizers") {
+		// Add also replaces, and unlike replace works when the server omitted the empty list
+		ops = append(ops, &AddOperation{
+			Path:  "/metadata/finalizers",
+			Value: schemaSetToStringArray(d.Get("metadata.0.finalizers").(*schema.Set)),
+		})
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
@@ -89,12 +112,12 @@ func resourceKubernetesNamespaceUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	name := d.Id()
 	log.Printf("[INFO] Deleting namespace: %#v", name)
 	err := conn.CoreV1().Namespaces().Delete(name, &meta_v1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -128,7 +151,7 @@ func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesNamespaceExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	name := d.Id()
 	log.Printf("[INFO] Checking namespace %s", name)
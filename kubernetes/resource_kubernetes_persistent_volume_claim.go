@@ -10,7 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -30,6 +30,7 @@ func resourceKubernetesPersistentVolumeClaim() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: persistentVolumeClaimSpecFields(false),
@@ -37,7 +38,7 @@ func resourceKubernetesPersistentVolumeClaim() *schema.Resource {
 }
 
 func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	spec, err := expandPersistentVolumeClaimSpec(d.Get("spec").([]interface{}))
@@ -105,7 +106,7 @@ func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta
 }
 
 func resourceKubernetesPersistentVolumeClaimRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -132,7 +133,7 @@ func resourceKubernetesPersistentVolumeClaimRead(d *schema.ResourceData, meta in
 }
 
 func resourceKubernetesPersistentVolumeClaimUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -157,7 +158,7 @@ func resourceKubernetesPersistentVolumeClaimUpdate(d *schema.ResourceData, meta
 }
 
 func resourceKubernetesPersistentVolumeClaimDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -166,18 +167,71 @@ func resourceKubernetesPersistentVolumeClaimDelete(d *schema.ResourceData, meta
 
 	log.Printf("[INFO] Deleting persistent volume claim: %#v", name)
 	err = conn.CoreV1().PersistentVolumeClaims(namespace).Delete(name, &meta_v1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	log.Printf("[INFO] Persistent volume claim %s deleted", name)
 
+	// Only wait on the volume when it's to be deleted, as the provider may not be allowed to read volumes
+	volumeName := d.Get("spec.0.volume_name").(string)
+	if volumeName != "" && d.Get("delete_released_volume").(bool) {
+		err = waitForPersistentVolumeRelease(conn, volumeName, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return err
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
 
+// waitForPersistentVolumeRelease waits for the volume bound to a deleted claim
+// to leave the Bound phase, and deletes it if it's retained.
+func waitForPersistentVolumeRelease(conn *kubernetes.Clientset, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Target:  []string{"Released", "Available", "Failed", "Deleted"},
+		Pending: []string{"Bound", "Pending"},
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.CoreV1().PersistentVolumes().Get(name, meta_v1.GetOptions{})
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return &api.PersistentVolume{}, "Deleted", nil
+				}
+				log.Printf("[ERROR] Received error: %#v", err)
+				return out, "", err
+			}
+
+			statusPhase := fmt.Sprintf("%v", out.Status.Phase)
+			log.Printf("[DEBUG] Persistent volume %s status received: %#v", out.Name, statusPhase)
+			return out, statusPhase, nil
+		},
+	}
+	raw, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Failed to wait for persistent volume %s to be released: %s", name, err)
+	}
+	volume := raw.(*api.PersistentVolume)
+	if volume.Status.Phase == "" {
+		log.Printf("[INFO] Persistent volume %s was deleted with its claim", name)
+		return nil
+	}
+	log.Printf("[INFO] Persistent volume %s is %s with reclaim policy %s", name, volume.Status.Phase, volume.Spec.PersistentVolumeReclaimPolicy)
+
+	if volume.Status.Phase == api.VolumeReleased && volume.Spec.PersistentVolumeReclaimPolicy == api.PersistentVolumeReclaimRetain {
+		log.Printf("[INFO] Deleting released persistent volume: %#v", name)
+		err = conn.CoreV1().PersistentVolumes().Delete(name, &meta_v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.Printf("[INFO] Persistent volume %s deleted", name)
+	}
+	return nil
+}
+
 func resourceKubernetesPersistentVolumeClaimExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
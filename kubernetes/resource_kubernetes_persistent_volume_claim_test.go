@@ -8,8 +8,8 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 	storageapi "k8s.io/client-go/pkg/apis/storage/v1"
 )
@@ -304,6 +304,39 @@ func TestAccKubernetesPersistentVolumeClaim_googleCloud_volumeMatch(t *testing.T
 // 	})
 // }
 
+func TestAccKubernetesPersistentVolumeClaim_googleCloud_deleteReleasedVolume(t *testing.T) {
+	var pvcConf api.PersistentVolumeClaim
+
+	claimName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	volumeName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	diskName := fmt.Sprintf("tf-acc-test-disk-%s", acctest.RandString(10))
+	zone := os.Getenv("GOOGLE_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); skipIfNoGoogleCloudSettingsFound(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPersistentVolumeClaimDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_deleteReleasedVolume(volumeName, claimName, diskName, zone, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeClaimExists("kubernetes_persistent_volume_claim.test", &pvcConf),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.volume_name", volumeName),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "delete_released_volume", "true"),
+				),
+			},
+			// Dropping the claim also deletes the retained volume, so Terraform plans to recreate it
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_deleteReleasedVolume(volumeName, claimName, diskName, zone, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeDeleted(volumeName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPersistentVolumeClaim_googleCloud_volumeUpdate(t *testing.T) {
 	var pvcConf api.PersistentVolumeClaim
 	var pvConf api.PersistentVolume
@@ -446,7 +479,7 @@ func TestAccKubernetesPersistentVolumeClaim_googleCloud_storageClass(t *testing.
 }
 
 func testAccCheckKubernetesPersistentVolumeClaimDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_persistent_volume_claim" {
@@ -476,7 +509,7 @@ func testAccCheckKubernetesPersistentVolumeClaimExists(n string, obj *api.Persis
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
@@ -493,6 +526,20 @@ func testAccCheckKubernetesPersistentVolumeClaimExists(n string, obj *api.Persis
 	}
 }
 
+func testAccCheckKubernetesPersistentVolumeDeleted(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		_, err := conn.CoreV1().PersistentVolumes().Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Persistent volume %s still exists", name)
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+}
+
 func testAccCheckClaimRef(pv *api.PersistentVolume, expected *ObjectRefStatic) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		or := pv.Spec.ClaimRef
@@ -675,6 +722,58 @@ resource "kubernetes_persistent_volume_claim" "test" {
 `, volumeName, diskName, zone, claimName)
 }
 
+func testAccKubernetesPersistentVolumeClaimConfig_deleteReleasedVolume(volumeName, claimName, diskName, zone string, withClaim bool) string {
+	config := fmt.Sprintf(`
+resource "kubernetes_persistent_volume" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		capacity {
+			storage = "10Gi"
+		}
+		access_modes = ["ReadWriteOnce"]
+		persistent_volume_reclaim_policy = "Retain"
+		storage_class_name = "standard"
+		persistent_volume_source {
+			gce_persistent_disk {
+				pd_name = "${google_compute_disk.test.name}"
+			}
+		}
+	}
+}
+
+resource "google_compute_disk" "test" {
+  name  = "%s"
+  type  = "pd-ssd"
+  zone  = "%s"
+  image = "debian-8-jessie-v20170523"
+  size = 10
+}
+`, volumeName, diskName, zone)
+	if !withClaim {
+		return config
+	}
+	return config + fmt.Sprintf(`
+resource "kubernetes_persistent_volume_claim" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		access_modes = ["ReadWriteOnce"]
+		storage_class_name = "standard"
+		resources {
+			requests {
+				storage = "5Gi"
+			}
+		}
+		volume_name = "${kubernetes_persistent_volume.test.metadata.0.name}"
+	}
+	delete_released_volume = true
+}
+`, claimName)
+}
+
 func testAccKubernetesPersistentVolumeClaimConfig_volumeMatch_modified(volumeName, claimName, diskName, zone string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_persistent_volume" "test2" {
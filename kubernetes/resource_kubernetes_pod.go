@@ -11,7 +11,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -25,6 +24,7 @@ func resourceKubernetesPod() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: podSpecCustomizeDiff("spec.0."),
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("pod", true),
 			"spec": {
@@ -40,7 +40,7 @@ func resourceKubernetesPod() *schema.Resource {
 	}
 }
 func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	spec, err := expandPodSpec(d.Get("spec").([]interface{}))
@@ -93,7 +93,7 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceKubernetesPodUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -126,7 +126,7 @@ func resourceKubernetesPodUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -190,7 +190,7 @@ func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceKubernetesPodDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -199,7 +199,7 @@ func resourceKubernetesPodDelete(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Deleting pod: %#v", name)
 	err = conn.CoreV1().Pods(namespace).Delete(name, nil)
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -227,7 +227,7 @@ func resourceKubernetesPodDelete(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceKubernetesPodExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -0,0 +1,209 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	api "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+func resourceKubernetesPodDisruptionBudget() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesPodDisruptionBudgetCreate,
+		Read:   resourceKubernetesPodDisruptionBudgetRead,
+		Exists: resourceKubernetesPodDisruptionBudgetExists,
+		Update: resourceKubernetesPodDisruptionBudgetUpdate,
+		Delete: resourceKubernetesPodDisruptionBudgetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("pod disruption budget", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec defines the behavior of the pod disruption budget. The spec can't be updated before Kubernetes 1.15, so changing it replaces the pod disruption budget. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_unavailable": {
+							Type:          schema.TypeString,
+							Description:   "An eviction is allowed if at most this many of the selected pods are unavailable after it, either an absolute number, e.g. `1`, or a percentage of the selected pods, e.g. `25%`. Conflicts with `min_available`.",
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"spec.0.min_available"},
+							ValidateFunc:  validateIntOrPercent,
+						},
+						"min_available": {
+							Type:          schema.TypeString,
+							Description:   "An eviction is allowed if at least this many of the selected pods are still available after it, either an absolute number, e.g. `2`, or a percentage of the selected pods, e.g. `75%`. Conflicts with `max_unavailable`.",
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"spec.0.max_unavailable"},
+							ValidateFunc:  validateIntOrPercent,
+						},
+						"selector": {
+							Type:        schema.TypeList,
+							Description: "A label query over the pods whose evictions are managed by the disruption budget. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(false),
+							},
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "Most recently observed status of the pod disruption budget. Read-only.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"current_healthy": {
+							Type:        schema.TypeInt,
+							Description: "Current number of healthy pods.",
+							Computed:    true,
+						},
+						"desired_healthy": {
+							Type:        schema.TypeInt,
+							Description: "Minimum desired number of healthy pods.",
+							Computed:    true,
+						},
+						"disruptions_allowed": {
+							Type:        schema.TypeInt,
+							Description: "Number of pod disruptions that are currently allowed.",
+							Computed:    true,
+						},
+						"expected_pods": {
+							Type:        schema.TypeInt,
+							Description: "Total number of pods counted by this disruption budget.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesPodDisruptionBudgetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	pdb := api.PodDisruptionBudget{
+		ObjectMeta: metadata,
+		Spec:       expandPodDisruptionBudgetSpec(d.Get("spec").([]interface{})),
+	}
+	// Checked on apply rather than on plan, where interpolated values look unset.
+	// Kubernetes would otherwise default min_available to 1, which would show a diff.
+	if pdb.Spec.MinAvailable == nil && pdb.Spec.MaxUnavailable == nil {
+		return fmt.Errorf("spec.0: one of min_available or max_unavailable must be set")
+	}
+	log.Printf("[INFO] Creating new pod disruption budget: %#v", pdb)
+	out, err := conn.PolicyV1beta1().PodDisruptionBudgets(metadata.Namespace).Create(&pdb)
+	if err != nil {
+		return fmt.Errorf("Failed to create pod disruption budget: %s", err)
+	}
+
+	log.Printf("[INFO] Submitted new pod disruption budget: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodDisruptionBudgetRead(d, meta)
+}
+
+func resourceKubernetesPodDisruptionBudgetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Reading pod disruption budget %s", name)
+	pdb, err := conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received pod disruption budget: %#v", pdb)
+	err = d.Set("metadata", flattenMetadata(pdb.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("spec", flattenPodDisruptionBudgetSpec(pdb.Spec))
+	if err != nil {
+		return err
+	}
+
+	return d.Set("status", flattenPodDisruptionBudgetStatus(pdb.Status))
+}
+
+func resourceKubernetesPodDisruptionBudgetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// The spec is ForceNew, only the metadata is left to update
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating pod disruption budget %q: %v", name, string(data))
+	out, err := conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update pod disruption budget: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated pod disruption budget: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodDisruptionBudgetRead(d, meta)
+}
+
+func resourceKubernetesPodDisruptionBudgetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Deleting pod disruption budget: %#v", name)
+	err = conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	log.Printf("[INFO] Pod disruption budget %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesPodDisruptionBudgetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking pod disruption budget %s", name)
+	_, err = conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
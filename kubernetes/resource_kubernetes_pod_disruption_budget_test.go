@@ -0,0 +1,290 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+func TestAccKubernetesPodDisruptionBudget_basic(t *testing.T) {
+	var conf api.PodDisruptionBudget
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_pod_disruption_budget.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesPodDisruptionBudgetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodDisruptionBudgetConfig_minAvailable(name, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodDisruptionBudgetExists("kubernetes_pod_disruption_budget.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "metadata.0.labels.TestLabelOne", "one"),
+					testAccCheckMetaLabels(&conf.ObjectMeta, map[string]string{"TestLabelOne": "one"}),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_disruption_budget.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_disruption_budget.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_disruption_budget.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_disruption_budget.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "spec.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "spec.0.min_available", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "spec.0.selector.0.match_labels.app", name),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "status.#", "1"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_disruption_budget.test", "status.0.disruptions_allowed"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_disruption_budget.test", "status.0.expected_pods"),
+				),
+			},
+			{
+				Config: testAccKubernetesPodDisruptionBudgetConfig_maxUnavailable(name, "25%"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodDisruptionBudgetExists("kubernetes_pod_disruption_budget.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "metadata.0.labels.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "metadata.0.labels.TestLabelTwo", "two"),
+					testAccCheckMetaLabels(&conf.ObjectMeta, map[string]string{"TestLabelOne": "one", "TestLabelTwo": "two"}),
+					resource.TestCheckResourceAttr("kubernetes_pod_disruption_budget.test", "spec.0.max_unavailable", "25%"),
+					func(s *terraform.State) error {
+						if conf.Spec.MinAvailable != nil {
+							return fmt.Errorf("Expected no min available, given %s", conf.Spec.MinAvailable.String())
+						}
+						if conf.Spec.MaxUnavailable == nil || conf.Spec.MaxUnavailable.String() != "25%" {
+							return fmt.Errorf("Expected max unavailable 25%%, given %#v", conf.Spec.MaxUnavailable)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPodDisruptionBudget_importBasic(t *testing.T) {
+	resourceName := "kubernetes_pod_disruption_budget.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDisruptionBudgetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodDisruptionBudgetConfig_minAvailable(name, "50%"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPodDisruptionBudget_minAvailableAndMaxUnavailable(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDisruptionBudgetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesPodDisruptionBudgetConfig_both(name),
+				ExpectError: regexp.MustCompile("conflicts with"),
+			},
+		},
+	})
+}
+
+func TestResourceKubernetesPodDisruptionBudgetValidate(t *testing.T) {
+	testCases := []struct {
+		Spec          map[string]interface{}
+		ExpectedError string
+	}{
+		{map[string]interface{}{"min_available": "1"}, ""},
+		{map[string]interface{}{"max_unavailable": "25%"}, ""},
+		{map[string]interface{}{"min_available": "${var.min_available}"}, ""},
+		{map[string]interface{}{}, ""},
+		{map[string]interface{}{"min_available": "1", "max_unavailable": "1"}, "conflicts with"},
+		{map[string]interface{}{"min_available": "${var.min_available}", "max_unavailable": "1"}, "conflicts with"},
+	}
+	for _, tc := range testCases {
+		tc.Spec["selector"] = []interface{}{map[string]interface{}{
+			"match_labels": map[string]interface{}{"app": "web"},
+		}}
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "web"}},
+			"spec":     []interface{}{tc.Spec},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = raw.Interpolate(map[string]ast.Variable{
+			"var.min_available": {Value: config.UnknownVariableValue, Type: ast.TypeUnknown},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := resourceKubernetesPodDisruptionBudget()
+		_, es := r.Validate(terraform.NewResourceConfig(raw))
+		if tc.ExpectedError == "" {
+			if len(es) > 0 {
+				t.Fatalf("Unexpected errors for spec %#v: %v", tc.Spec, es)
+			}
+			// Unknown values must not be mistaken for unset ones at plan time
+			_, err = r.Diff(nil, terraform.NewResourceConfig(raw), nil)
+			if err != nil {
+				t.Fatalf("Unexpected error for spec %#v: %s", tc.Spec, err)
+			}
+			continue
+		}
+		if len(es) == 0 || !strings.Contains(es[0].Error(), tc.ExpectedError) {
+			t.Fatalf("Expected an error containing %q for spec %#v, given: %v", tc.ExpectedError, tc.Spec, es)
+		}
+	}
+}
+
+func TestResourceKubernetesPodDisruptionBudgetCreate_noBudget(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesPodDisruptionBudget().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "web"}},
+		"spec": []interface{}{map[string]interface{}{
+			"selector": []interface{}{map[string]interface{}{
+				"match_labels": map[string]interface{}{"app": "web"},
+			}},
+		}},
+	})
+	// Fails before reaching the API
+	err := resourceKubernetesPodDisruptionBudgetCreate(d, &kubeProvider{})
+	if err == nil || !strings.Contains(err.Error(), "must be set") {
+		t.Fatalf("Expected an error containing %q, given: %v", "must be set", err)
+	}
+}
+
+func testAccCheckKubernetesPodDisruptionBudgetDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_pod_disruption_budget" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Pod disruption budget still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesPodDisruptionBudgetExists(n string, obj *api.PodDisruptionBudget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesPodDisruptionBudgetConfig_minAvailable(name, minAvailable string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod_disruption_budget" "test" {
+  metadata {
+    name = "%s"
+
+    labels {
+      TestLabelOne = "one"
+    }
+  }
+
+  spec {
+    min_available = "%s"
+
+    selector {
+      match_labels {
+        app = "%s"
+      }
+    }
+  }
+}
+`, name, minAvailable, name)
+}
+
+func testAccKubernetesPodDisruptionBudgetConfig_maxUnavailable(name, maxUnavailable string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod_disruption_budget" "test" {
+  metadata {
+    name = "%s"
+
+    labels {
+      TestLabelOne = "one"
+      TestLabelTwo = "two"
+    }
+  }
+
+  spec {
+    max_unavailable = "%s"
+
+    selector {
+      match_labels {
+        app = "%s"
+      }
+    }
+  }
+}
+`, name, maxUnavailable, name)
+}
+
+func testAccKubernetesPodDisruptionBudgetConfig_both(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod_disruption_budget" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    min_available   = "1"
+    max_unavailable = "1"
+
+    selector {
+      match_labels {
+        app = "%s"
+      }
+    }
+  }
+}
+`, name, name)
+}
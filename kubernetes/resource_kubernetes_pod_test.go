@@ -3,10 +3,10 @@ package kubernetes
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -216,6 +216,33 @@ func TestAccKubernetesPod_with_container_liveness_probe_using_http_get(t *testin
 	})
 }
 
+func TestAccKubernetesPod_with_container_minimal_readiness_probe(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithMinimalReadinessProbe(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.readiness_probe.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.readiness_probe.0.failure_threshold", "3"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.readiness_probe.0.initial_delay_seconds", "0"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.readiness_probe.0.period_seconds", "10"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.readiness_probe.0.success_threshold", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.readiness_probe.0.timeout_seconds", "1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_with_container_liveness_probe_using_tcp(t *testing.T) {
 	var conf api.Pod
 
@@ -294,8 +321,8 @@ func TestAccKubernetesPod_with_container_security_context(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.se_linux_options.0.level", "s0:c123,c456"),
 					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.#", "1"),
 					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.add.#", "2"),
-					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.add.0", "NET_ADMIN"),
-					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.add.1", "SYS_TIME"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.add.35540975", "NET_ADMIN"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.add.1088032646", "SYS_TIME"),
 				),
 			},
 		},
@@ -390,6 +417,21 @@ func TestAccKubernetesPod_with_resource_requirements(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_multiple_volume_sources(t *testing.T) {
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesPodConfigWithMultipleVolumeSources(podName, "nginx:1.7.9"),
+				ExpectError: regexp.MustCompile(`volume "cache-volume" must have exactly one source, found empty_dir, host_path`),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_with_empty_dir_volume(t *testing.T) {
 	var conf api.Pod
 
@@ -442,6 +484,169 @@ func TestAccKubernetesPod_with_secret_vol_items(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_tolerations(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithTolerations(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.effect", "NoSchedule"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.key", "nvidia.com/gpu"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.operator", "Equal"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.value", "present"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.1.effect", "NoExecute"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.1.key", "dedicated"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.1.operator", "Exists"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.1.toleration_seconds", "120"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_hostNetworkDNSPolicy(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithHostNetworkDNSPolicy(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.host_network", "true"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.dns_policy", "ClusterFirstWithHostNet"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_env_from(t *testing.T) {
+	var conf api.Pod
+
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithEnvFrom(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.0.prefix", "APP_"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.0.config_map_ref.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.0.config_map_ref.0.optional", "false"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.1.prefix", ""),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.1.secret_ref.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.1.secret_ref.0.optional", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_limitRangeInjectedLimits(t *testing.T) {
+	var conf api.Pod
+
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithLimitRange(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.0.cpu", "50m"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.0.cpu", "200m"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.0.memory", "512M"),
+				),
+			},
+			{
+				Config:   testAccKubernetesPodConfigWithLimitRange(name, imageName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_resourceFieldRefEnv(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithResourceFieldRefEnv(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.0.name", "MEMORY_LIMIT_MB"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.0.value_from.0.resource_field_ref.0.resource", "limits.memory"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.0.value_from.0.resource_field_ref.0.divisor", "1Mi"),
+					func(s *terraform.State) error {
+						ref := conf.Spec.Containers[0].Env[0].ValueFrom.ResourceFieldRef
+						if ref.Divisor.String() != "1Mi" {
+							return fmt.Errorf("Expected divisor 1Mi, given %s", ref.Divisor.String())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_fieldRefLabelEnv(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithFieldRefLabelEnv(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.0.value_from.0.field_ref.0.field_path", "metadata.labels['app']"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.1.value_from.0.field_ref.0.field_path", "metadata.annotations['example.com/team']"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_gke_with_nodeSelector(t *testing.T) {
 	var conf api.Pod
 
@@ -468,7 +673,7 @@ func TestAccKubernetesPod_gke_with_nodeSelector(t *testing.T) {
 }
 
 func testAccCheckKubernetesPodDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_pod" {
@@ -498,7 +703,7 @@ func testAccCheckKubernetesPodExists(n string, obj *api.Pod) resource.TestCheckF
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
@@ -676,6 +881,29 @@ resource "kubernetes_pod" "test" {
 	`, podName, imageName)
 }
 
+func testAccKubernetesPodConfigWithMinimalReadinessProbe(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      readiness_probe {
+        tcp_socket {
+          port = 80
+        }
+      }
+    }
+  }
+}
+	`, podName, imageName)
+}
+
 func testAccKubernetesPodConfigWithLivenessProbeUsingTCP(podName, imageName string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
@@ -992,6 +1220,67 @@ resource "kubernetes_pod" "test" {
 `, podName, imageName)
 }
 
+func testAccKubernetesPodConfigWithMultipleVolumeSources(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      volume_mount {
+        mount_path =  "/cache"
+        name =  "cache-volume"
+      }
+    }
+    volume {
+      name = "cache-volume"
+      empty_dir = {
+        medium = "Memory"
+      }
+      host_path = {
+        path = "/tmp/cache"
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithTolerations(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+
+    toleration {
+      effect   = "NoSchedule"
+      key      = "nvidia.com/gpu"
+      operator = "Equal"
+      value    = "present"
+    }
+
+    toleration {
+      effect             = "NoExecute"
+      key                = "dedicated"
+      operator           = "Exists"
+      toleration_seconds = "120"
+    }
+  }
+}
+`, podName, imageName)
+}
+
 func testAccKubernetesPodConfigNodeSelector(podName, imageName, region string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
@@ -1029,3 +1318,192 @@ resource "kubernetes_pod" "test" {
 }
 `, podName, imageName, args)
 }
+
+func testAccKubernetesPodConfigWithHostNetworkDNSPolicy(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    host_network = true
+    dns_policy   = "ClusterFirstWithHostNet"
+
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithEnvFrom(name, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    LOG_LEVEL = "debug"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      env_from {
+        prefix = "APP_"
+
+        config_map_ref {
+          name = "${kubernetes_config_map.test.metadata.0.name}"
+        }
+      }
+
+      env_from {
+        secret_ref {
+          name     = "%s"
+          optional = true
+        }
+      }
+    }
+  }
+}
+`, name, name, imageName, name)
+}
+
+func testAccKubernetesPodConfigWithLimitRange(name, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+  metadata {
+    name = "%s"
+  }
+}
+
+resource "kubernetes_limit_range" "test" {
+  metadata {
+    name      = "%s"
+    namespace = "${kubernetes_namespace.test.metadata.0.name}"
+  }
+
+  spec {
+    limit {
+      type = "Container"
+
+      default {
+        cpu    = "200m"
+        memory = "512M"
+      }
+    }
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    name      = "%s"
+    namespace = "${kubernetes_limit_range.test.metadata.0.namespace}"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      resources {
+        requests {
+          cpu = "50m"
+        }
+      }
+    }
+  }
+}
+`, name, name, name, imageName)
+}
+
+func testAccKubernetesPodConfigWithResourceFieldRefEnv(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      env {
+        name = "MEMORY_LIMIT_MB"
+
+        value_from {
+          resource_field_ref {
+            resource = "limits.memory"
+            divisor  = "1Mi"
+          }
+        }
+      }
+
+      resources {
+        limits {
+          memory = "128Mi"
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithFieldRefLabelEnv(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+
+    labels {
+      app = "web"
+    }
+
+    annotations {
+      "example.com/team" = "platform"
+    }
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      env {
+        name = "APP"
+
+        value_from {
+          field_ref {
+            field_path = "metadata.labels['app']"
+          }
+        }
+      }
+
+      env {
+        name = "TEAM"
+
+        value_from {
+          field_ref {
+            field_path = "metadata.annotations['example.com/team']"
+          }
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
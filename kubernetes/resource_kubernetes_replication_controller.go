@@ -24,6 +24,7 @@ func resourceKubernetesReplicationController() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: podSpecCustomizeDiff("spec.0.template.0."),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -74,7 +75,7 @@ func resourceKubernetesReplicationController() *schema.Resource {
 }
 
 func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
@@ -114,7 +115,7 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 }
 
 func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -148,7 +149,7 @@ func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta in
 }
 
 func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -189,7 +190,7 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 }
 
 func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -210,6 +211,11 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 	}
 	_, err = conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Replication controller %s was already deleted", name)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -221,7 +227,7 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 	}
 
 	err = conn.CoreV1().ReplicationControllers(namespace).Delete(name, &metav1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -232,7 +238,7 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 }
 
 func resourceKubernetesReplicationControllerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -0,0 +1,291 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rbacv1beta1 "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+func TestAccKubernetesRole_basic(t *testing.T) {
+	var conf rbacv1beta1.Role
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_role.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesRoleConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesRoleExists("kubernetes_role.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "metadata.0.labels.TestLabelOne", "one"),
+					resource.TestCheckResourceAttrSet("kubernetes_role.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_role.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.api_groups.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.api_groups.0", ""),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.resources.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.resources.0", "pods"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.resources.1", "pods/log"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.verbs.#", "3"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.1.api_groups.0", "extensions"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.1.resources.0", "deployments"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.1.resource_names.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.1.resource_names.0", "web"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.1.verbs.#", "2"),
+					testAccCheckRoleRules(&conf, []rbacv1beta1.PolicyRule{
+						{
+							APIGroups: []string{""},
+							Resources: []string{"pods", "pods/log"},
+							Verbs:     []string{"get", "list", "watch"},
+						},
+						{
+							APIGroups:     []string{"extensions"},
+							Resources:     []string{"deployments"},
+							ResourceNames: []string{"web"},
+							Verbs:         []string{"get", "patch"},
+						},
+					}),
+				),
+			},
+			{
+				Config: testAccKubernetesRoleConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesRoleExists("kubernetes_role.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.resources.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.resources.0", "configmaps"),
+					resource.TestCheckResourceAttr("kubernetes_role.test", "policy_rule.0.resource_names.#", "0"),
+					testAccCheckRoleRules(&conf, []rbacv1beta1.PolicyRule{
+						{
+							APIGroups: []string{""},
+							Resources: []string{"configmaps"},
+							Verbs:     []string{"get"},
+						},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesRole_importBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	// The role is created outside of Terraform, so it's not part of the state to destroy
+	defer func() {
+		if meta := testAccProvider.Meta(); meta != nil {
+			meta.(*kubeProvider).Clientset.RbacV1beta1().Roles("default").Delete(name, &meta_v1.DeleteOptions{})
+		}
+	}()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*kubeProvider).Clientset
+					_, err := conn.RbacV1beta1().Roles("default").Create(&rbacv1beta1.Role{
+						ObjectMeta: meta_v1.ObjectMeta{
+							Name:   name,
+							Labels: map[string]string{"TestLabelOne": "one"},
+						},
+						Rules: []rbacv1beta1.PolicyRule{
+							{
+								APIGroups: []string{""},
+								Resources: []string{"pods", "pods/log"},
+								Verbs:     []string{"get", "list", "watch"},
+							},
+							{
+								APIGroups:     []string{"extensions"},
+								Resources:     []string{"deployments"},
+								ResourceNames: []string{"web"},
+								Verbs:         []string{"get", "patch"},
+							},
+						},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:        testAccKubernetesRoleConfig_basic(name),
+				ResourceName:  "kubernetes_role.test",
+				ImportState:   true,
+				ImportStateId: "default/" + name,
+				ImportStateCheck: testAccCheckImportedAttributes(map[string]string{
+					"id":                             "default/" + name,
+					"metadata.0.name":                name,
+					"metadata.0.namespace":           "default",
+					"metadata.0.labels.TestLabelOne": "one",
+					"policy_rule.#":                  "2",
+					"policy_rule.0.api_groups.#":     "1",
+					"policy_rule.0.api_groups.0":     "",
+					"policy_rule.0.resources.#":      "2",
+					"policy_rule.0.resources.1":      "pods/log",
+					"policy_rule.0.verbs.#":          "3",
+					"policy_rule.1.api_groups.0":     "extensions",
+					"policy_rule.1.resource_names.#": "1",
+					"policy_rule.1.resource_names.0": "web",
+					"policy_rule.1.verbs.1":          "patch",
+				}),
+			},
+		},
+	})
+}
+
+func TestExpandPolicyRules(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"api_groups":        []interface{}{""},
+			"non_resource_urls": []interface{}{},
+			"resource_names":    []interface{}{},
+			"resources":         []interface{}{"pods"},
+			"verbs":             []interface{}{"get", "list"},
+		},
+	}
+	expected := []rbacv1beta1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		},
+	}
+
+	out := expandPolicyRules(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected policy rules.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+
+	flattened := flattenPolicyRules(out)
+	expectedFlattened := []interface{}{
+		map[string]interface{}{
+			"api_groups": []string{""},
+			"resources":  []string{"pods"},
+			"verbs":      []string{"get", "list"},
+		},
+	}
+	if !reflect.DeepEqual(flattened, expectedFlattened) {
+		t.Fatalf("Unexpected flattened policy rules.\nExpected: %#v\nGiven:    %#v", expectedFlattened, flattened)
+	}
+}
+
+func testAccCheckRoleRules(role *rbacv1beta1.Role, expected []rbacv1beta1.PolicyRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !reflect.DeepEqual(role.Rules, expected) {
+			return fmt.Errorf("Role rules don't match.\nExpected: %#v\nGiven: %#v", expected, role.Rules)
+		}
+		return nil
+	}
+}
+
+func testAccCheckImportedAttributes(expected map[string]string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("Expected 1 imported state, given %d", len(states))
+		}
+		for k, v := range expected {
+			if given, ok := states[0].Attributes[k]; !ok || given != v {
+				return fmt.Errorf("Expected imported %s to be %q, given %q", k, v, given)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesRoleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_role" {
+			continue
+		}
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		resp, err := conn.RbacV1beta1().Roles(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Role still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesRoleExists(n string, obj *rbacv1beta1.Role) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		out, err := conn.RbacV1beta1().Roles(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesRoleConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_role" "test" {
+	metadata {
+		labels {
+			TestLabelOne = "one"
+		}
+		name = "%s"
+	}
+
+	policy_rule {
+		api_groups = [""]
+		resources  = ["pods", "pods/log"]
+		verbs      = ["get", "list", "watch"]
+	}
+
+	policy_rule {
+		api_groups     = ["extensions"]
+		resources      = ["deployments"]
+		resource_names = ["web"]
+		verbs          = ["get", "patch"]
+	}
+}`, name)
+}
+
+func testAccKubernetesRoleConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_role" "test" {
+	metadata {
+		labels {
+			TestLabelOne = "one"
+		}
+		name = "%s"
+	}
+
+	policy_rule {
+		api_groups = [""]
+		resources  = ["configmaps"]
+		verbs      = ["get"]
+	}
+}`, name)
+}
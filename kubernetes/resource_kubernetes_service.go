@@ -10,7 +10,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -24,6 +23,7 @@ func resourceKubernetesService() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceKubernetesServiceCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service", true),
@@ -45,14 +45,31 @@ func resourceKubernetesService() *schema.Resource {
 							Type:        schema.TypeSet,
 							Description: "A list of IP addresses for which nodes in the cluster will also accept traffic for this service. These IPs are not managed by Kubernetes. The user is responsible for ensuring that traffic arrives at a node with this IP.  A common example is external load-balancers that are not part of the Kubernetes system.",
 							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Set:         schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateIPAddress,
+							},
+							Set: schema.HashString,
 						},
 						"external_name": {
 							Type:        schema.TypeString,
 							Description: "The external reference that kubedns or equivalent will return as a CNAME record for this service. No proxying will be involved. Must be a valid DNS name and requires `type` to be `ExternalName`.",
 							Optional:    true,
 						},
+						"external_traffic_policy": {
+							Type:         schema.TypeString,
+							Description:  "Denotes if this service desires to route external traffic to node-local or cluster-wide endpoints. `Local` preserves the client source IP and avoids a second hop for `LoadBalancer` and `NodePort` type services, but risks potentially imbalanced traffic spreading. `Cluster` obscures the client source IP and may cause a second hop to another node, but should have good overall load-spreading. More info: https://kubernetes.io/docs/tutorials/services/source-ip/",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Local", "Cluster"}),
+						},
+						"health_check_node_port": {
+							Type:         schema.TypeInt,
+							Description:  "The node port serving the health checks of the load balancer, when `external_traffic_policy` is `Local` and `type` is `LoadBalancer`. Allocated by the system if unset. Can only be set together with `external_traffic_policy = \"Local\"`.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validatePortNum,
+						},
 						"load_balancer_ip": {
 							Type:        schema.TypeString,
 							Description: "Only applies to `type = LoadBalancer`. LoadBalancer will get created with the IP specified in this field. This feature depends on whether the underlying cloud-provider supports specifying this field when a load balancer is created. This field will be ignored if the cloud-provider does not support the feature.",
@@ -109,10 +126,12 @@ func resourceKubernetesService() *schema.Resource {
 							Optional:    true,
 						},
 						"session_affinity": {
-							Type:        schema.TypeString,
-							Description: "Used to maintain session affinity. Supports `ClientIP` and `None`. Defaults to `None`. More info: http://kubernetes.io/docs/user-guide/services#virtual-ips-and-service-proxies",
-							Optional:    true,
-							Default:     "None",
+							Type:             schema.TypeString,
+							Description:      "Used to maintain session affinity. Supports `ClientIP` and `None`. Defaults to `None`. More info: http://kubernetes.io/docs/user-guide/services#virtual-ips-and-service-proxies",
+							Optional:         true,
+							Default:          "None",
+							ValidateFunc:     validateAttributeValueIsIn([]string{"ClientIP", "None"}),
+							DiffSuppressFunc: suppressDefaultSessionAffinity,
 						},
 						"type": {
 							Type:        schema.TypeString,
@@ -143,8 +162,26 @@ func resourceKubernetesService() *schema.Resource {
 	}
 }
 
+func resourceKubernetesServiceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// The port is computed, so it's only checked when it's configured
+	if !d.HasChange("spec.0.health_check_node_port") {
+		return nil
+	}
+	return validateServiceHealthCheckNodePort(
+		d.Get("spec.0.external_traffic_policy").(string),
+		d.Get("spec.0.health_check_node_port").(int))
+}
+
+func validateServiceHealthCheckNodePort(policy string, port int) error {
+	if port != 0 && policy != string(api.ServiceExternalTrafficPolicyTypeLocal) {
+		return fmt.Errorf("spec.0.health_check_node_port can only be set when spec.0.external_traffic_policy is %q",
+			api.ServiceExternalTrafficPolicyTypeLocal)
+	}
+	return nil
+}
+
 func resourceKubernetesServiceCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	svc := api.Service{
@@ -192,7 +229,7 @@ func resourceKubernetesServiceCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -227,7 +264,7 @@ func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -262,7 +299,7 @@ func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -271,7 +308,7 @@ func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) e
 
 	log.Printf("[INFO] Deleting service: %#v", name)
 	err = conn.CoreV1().Services(namespace).Delete(name, &meta_v1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -282,7 +319,7 @@ func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesServiceExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
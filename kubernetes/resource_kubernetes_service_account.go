@@ -26,6 +26,10 @@ func resourceKubernetesServiceAccount() *schema.Resource {
 		// any way to differentiate between default & user-defined secret
 		// after the account was created.
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service account", true),
 			"image_pull_secret": {
@@ -60,12 +64,18 @@ func resourceKubernetesServiceAccount() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"wait_for_token": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait until the token of the generated default secret is populated. Fails the creation if it doesn't appear within the create timeout.",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
 
 func resourceKubernetesServiceAccountCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	svcAcc := api.ServiceAccount{
@@ -84,8 +94,10 @@ func resourceKubernetesServiceAccountCreate(d *schema.ResourceData, meta interfa
 
 	// Here we get the only chance to identify and store default secret name
 	// so we can avoid showing it in diff as it's not managed by Terraform
+	waitForToken := d.Get("wait_for_token").(bool)
+	timeout := d.Timeout(schema.TimeoutCreate)
 	var resp *api.ServiceAccount
-	err = resource.Retry(30*time.Second, func() *resource.RetryError {
+	err = resource.Retry(timeout, func() *resource.RetryError {
 		var err error
 		resp, err = conn.CoreV1().ServiceAccounts(out.Namespace).Get(out.Name, metav1.GetOptions{})
 		if err != nil {
@@ -96,6 +108,14 @@ func resourceKubernetesServiceAccountCreate(d *schema.ResourceData, meta interfa
 		}
 		return resource.RetryableError(fmt.Errorf("Waiting for default secret of %q to appear", d.Id()))
 	})
+	if err != nil {
+		if waitForToken {
+			return err
+		}
+		// Token secrets are generated asynchronously and not at all on newer clusters
+		log.Printf("[WARN] %s", err)
+		return resourceKubernetesServiceAccountRead(d, meta)
+	}
 
 	diff := diffObjectReferences(svcAcc.Secrets, resp.Secrets)
 	if len(diff) > 1 {
@@ -105,9 +125,34 @@ func resourceKubernetesServiceAccountCreate(d *schema.ResourceData, meta interfa
 	defaultSecret := diff[0]
 	d.Set("default_secret_name", defaultSecret.Name)
 
+	if waitForToken {
+		err = resource.Retry(timeout, waitForServiceAccountTokenFunc(conn, out.Namespace, defaultSecret.Name))
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesServiceAccountRead(d, meta)
 }
 
+// waitForServiceAccountTokenFunc waits for the token controller to populate
+// the token of the generated secret.
+func waitForServiceAccountTokenFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return func() *resource.RetryError {
+		secret, err := conn.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return resource.RetryableError(fmt.Errorf("Waiting for token secret %s/%s to be created", ns, name))
+			}
+			return resource.NonRetryableError(err)
+		}
+		if len(secret.Data[api.ServiceAccountTokenKey]) == 0 {
+			return resource.RetryableError(fmt.Errorf("Waiting for token of secret %s/%s to be populated", ns, name))
+		}
+		return nil
+	}
+}
+
 func diffObjectReferences(origOrs []api.ObjectReference, ors []api.ObjectReference) []api.ObjectReference {
 	var diff []api.ObjectReference
 	uniqueRefs := make(map[string]*api.ObjectReference, 0)
@@ -126,7 +171,7 @@ func diffObjectReferences(origOrs []api.ObjectReference, ors []api.ObjectReferen
 }
 
 func resourceKubernetesServiceAccountRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -156,7 +201,7 @@ func resourceKubernetesServiceAccountRead(d *schema.ResourceData, meta interface
 }
 
 func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -196,7 +241,7 @@ func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interfa
 }
 
 func resourceKubernetesServiceAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -205,7 +250,7 @@ func resourceKubernetesServiceAccountDelete(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] Deleting service account: %#v", name)
 	err = conn.CoreV1().ServiceAccounts(namespace).Delete(name, &metav1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -216,7 +261,7 @@ func resourceKubernetesServiceAccountDelete(d *schema.ResourceData, meta interfa
 }
 
 func resourceKubernetesServiceAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -187,6 +186,50 @@ func TestAccKubernetesServiceAccount_generatedName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesServiceAccount_waitForToken(t *testing.T) {
+	var conf api.ServiceAccount
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service_account.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceAccountConfig_waitForToken(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceAccountExists("kubernetes_service_account.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service_account.test", "wait_for_token", "true"),
+					resource.TestMatchResourceAttr("kubernetes_service_account.test", "default_secret_name", regexp.MustCompile("^"+name+"-token-[a-z0-9]+$")),
+					testAccCheckServiceAccountTokenPopulated("kubernetes_service_account.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServiceAccountTokenPopulated(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
+		namespace := rs.Primary.Attributes["metadata.0.namespace"]
+		name := rs.Primary.Attributes["default_secret_name"]
+		secret, err := conn.CoreV1().Secrets(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if len(secret.Data[api.ServiceAccountTokenKey]) == 0 {
+			return fmt.Errorf("Expected the token of secret %s/%s to be populated", namespace, name)
+		}
+		return nil
+	}
+}
+
 func testAccCheckServiceAccountImagePullSecrets(m *api.ServiceAccount, expected []*regexp.Regexp) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.ImagePullSecrets) == 0 {
@@ -240,7 +283,7 @@ func matchObjectReferenceName(lor []api.ObjectReference, expected []*regexp.Rege
 }
 
 func testAccCheckKubernetesServiceAccountDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_service_account" {
@@ -270,7 +313,7 @@ func testAccCheckKubernetesServiceAccountExists(n string, obj *api.ServiceAccoun
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
@@ -413,3 +456,13 @@ resource "kubernetes_service_account" "test" {
 	}
 }`, prefix)
 }
+
+func testAccKubernetesServiceAccountConfig_waitForToken(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service_account" "test" {
+	metadata {
+		name = "%s"
+	}
+	wait_for_token = true
+}`, name)
+}
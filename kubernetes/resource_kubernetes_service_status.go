@@ -0,0 +1,198 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func resourceKubernetesServiceStatus() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesServiceStatusCreate,
+		Read:   resourceKubernetesServiceStatusRead,
+		Exists: resourceKubernetesServiceStatusExists,
+		Update: resourceKubernetesServiceStatusUpdate,
+		Delete: resourceKubernetesServiceStatusDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Identifies the existing service whose status is managed.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the service.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the service.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"load_balancer_ingress": {
+				Type:        schema.TypeList,
+				Description: "List of ingress points for the load balancer of the service. Traffic intended for the service should be sent to these ingress points.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Type:         schema.TypeString,
+							Description:  "IP address of a load balancer ingress point, typically used by load balancers that are IP based.",
+							Optional:     true,
+							ValidateFunc: validateIPAddress,
+						},
+						"hostname": {
+							Type:         schema.TypeString,
+							Description:  "Hostname of a load balancer ingress point, typically used by load balancers that are DNS based.",
+							Optional:     true,
+							ValidateFunc: validateHostname,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesServiceStatusCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	om := meta_v1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+
+	// The ID is only set once the status is updated, as a tainted resource
+	// would clear the ingress points of the service when replaced
+	ingress, err := expandLoadBalancerIngress(d.Get("load_balancer_ingress").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	err = updateServiceLoadBalancerIngress(conn, om.Namespace, om.Name, ingress)
+	if err != nil {
+		return fmt.Errorf("Failed to update service status: %s", err)
+	}
+	d.SetId(buildId(om))
+
+	return resourceKubernetesServiceStatusRead(d, meta)
+}
+
+func resourceKubernetesServiceStatusRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading status of service %s", name)
+	svc, err := conn.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received service status: %#v", svc.Status)
+
+	err = d.Set("metadata", []interface{}{map[string]interface{}{
+		"name":      svc.Name,
+		"namespace": svc.Namespace,
+	}})
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("load_balancer_ingress", flattenLoadBalancerIngress(svc.Status.LoadBalancer.Ingress))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesServiceStatusUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ingress, err := expandLoadBalancerIngress(d.Get("load_balancer_ingress").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	err = updateServiceLoadBalancerIngress(conn, namespace, name, ingress)
+	if err != nil {
+		return fmt.Errorf("Failed to update service status: %s", err)
+	}
+
+	return resourceKubernetesServiceStatusRead(d, meta)
+}
+
+func resourceKubernetesServiceStatusDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubeProvider).Clientset
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = updateServiceLoadBalancerIngress(conn, namespace, name, nil)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			log.Printf("[INFO] Service %s no longer exists, nothing to clear", name)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to clear service status: %s", err)
+	}
+
+	log.Printf("[INFO] Load balancer status of service %s cleared", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesServiceStatusExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	return resourceKubernetesServiceExists(d, meta)
+}
+
+// updateServiceLoadBalancerIngress replaces the load balancer ingress points of a
+// service through the status subresource, which ignores changes to the spec.
+func updateServiceLoadBalancerIngress(conn *kubernetes.Clientset, namespace, name string, ingress []v1.LoadBalancerIngress) error {
+	svc, err := conn.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	svc.Status.LoadBalancer.Ingress = ingress
+	log.Printf("[INFO] Updating status of service %q: %#v", name, svc.Status)
+	out, err := conn.CoreV1().Services(namespace).UpdateStatus(svc)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted updated service status: %#v", out.Status)
+
+	return nil
+}
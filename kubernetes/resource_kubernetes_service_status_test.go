@@ -0,0 +1,159 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func TestAccKubernetesServiceStatus_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service_status.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceStatusDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceStatusConfig_basic(name, `ip = "192.168.10.240"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "metadata.0.namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "load_balancer_ingress.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "load_balancer_ingress.0.ip", "192.168.10.240"),
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "load_balancer_ingress.0.hostname", ""),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceStatusConfig_basic(name, `hostname = "lb.example.com"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "load_balancer_ingress.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "load_balancer_ingress.0.ip", ""),
+					resource.TestCheckResourceAttr("kubernetes_service_status.test", "load_balancer_ingress.0.hostname", "lb.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesServiceStatus_importBasic(t *testing.T) {
+	resourceName := "kubernetes_service_status.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesServiceStatusDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceStatusConfig_basic(name, `ip = "192.168.10.240"`),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestExpandLoadBalancerIngress(t *testing.T) {
+	cases := []struct {
+		Input         []interface{}
+		Expected      []api.LoadBalancerIngress
+		ExpectedError string
+	}{
+		{
+			[]interface{}{
+				map[string]interface{}{"ip": "192.168.10.240", "hostname": ""},
+				map[string]interface{}{"ip": "", "hostname": "lb.example.com"},
+			},
+			[]api.LoadBalancerIngress{{IP: "192.168.10.240"}, {Hostname: "lb.example.com"}},
+			"",
+		},
+		{
+			[]interface{}{
+				map[string]interface{}{"ip": "192.168.10.240", "hostname": ""},
+				map[string]interface{}{"ip": "", "hostname": ""},
+			},
+			nil,
+			"load_balancer_ingress.1: one of `ip` or `hostname` must be set",
+		},
+		{
+			[]interface{}{nil},
+			nil,
+			"load_balancer_ingress.0: one of `ip` or `hostname` must be set",
+		},
+	}
+
+	for _, tc := range cases {
+		out, err := expandLoadBalancerIngress(tc.Input)
+		if tc.ExpectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error containing %q for %#v, given: %v", tc.ExpectedError, tc.Input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(out, tc.Expected) {
+			t.Fatalf("Unexpected ingress points.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+		}
+	}
+}
+
+func testAccCheckKubernetesServiceStatusDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_service_status" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil && len(resp.Status.LoadBalancer.Ingress) > 0 {
+			return fmt.Errorf("Service status still has load balancer ingress points: %#v", resp.Status.LoadBalancer.Ingress)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesServiceStatusConfig_basic(name, ingress string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		port {
+			port = 8080
+			target_port = 80
+		}
+	}
+}
+
+resource "kubernetes_service_status" "test" {
+	metadata {
+		name = "${kubernetes_service.test.metadata.0.name}"
+	}
+	load_balancer_ingress {
+		%s
+	}
+}
+`, name, ingress)
+}
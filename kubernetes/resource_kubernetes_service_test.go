@@ -6,12 +6,12 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	kubernetes "k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -165,6 +165,128 @@ func TestAccKubernetesService_loadBalancer(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesService_externalTrafficPolicyLocal(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t); skipIfNoLoadBalancersAvailable(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_externalTrafficPolicy(name, "Local", 31942),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.external_traffic_policy", "Local"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.health_check_node_port", "31942"),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceConfig_externalTrafficPolicy(name, "Cluster", 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.external_traffic_policy", "Cluster"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.health_check_node_port", "0"),
+				),
+			},
+			{
+				Config:      testAccKubernetesServiceConfig_externalTrafficPolicy(name, "Cluster", 31942),
+				ExpectError: regexp.MustCompile("health_check_node_port can only be set when spec.0.external_traffic_policy is \"Local\""),
+			},
+		},
+	})
+}
+
+func TestValidateServiceHealthCheckNodePort(t *testing.T) {
+	cases := []struct {
+		Policy string
+		Port   int
+		Err    bool
+	}{
+		{"Local", 31942, false},
+		{"Local", 0, false},
+		{"Cluster", 0, false},
+		{"", 0, false},
+		{"Cluster", 31942, true},
+		{"", 31942, true},
+	}
+
+	for _, tc := range cases {
+		err := validateServiceHealthCheckNodePort(tc.Policy, tc.Port)
+		if (err != nil) != tc.Err {
+			t.Fatalf("Expected error to be %t for policy %q and port %d, got: %v", tc.Err, tc.Policy, tc.Port, err)
+		}
+	}
+}
+
+func TestResourceKubernetesService_externalIPsValidation(t *testing.T) {
+	cases := []struct {
+		Name        string
+		ExternalIPs []interface{}
+		Errors      int
+	}{
+		{"IPv4 addresses", []interface{}{"10.0.0.3", "10.0.0.4"}, 0},
+		{"IPv6 address", []interface{}{"2001:db8::68"}, 0},
+		{"hostname", []interface{}{"example.com"}, 1},
+		{"CIDR", []interface{}{"10.0.0.0/24"}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"external_ips": tc.ExternalIPs,
+					"port":         []map[string]interface{}{{"port": 80}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, es := resourceKubernetesService().Validate(terraform.NewResourceConfig(raw))
+			if len(es) != tc.Errors {
+				t.Fatalf("Expected %d errors, given: %v", tc.Errors, es)
+			}
+		})
+	}
+}
+
+func TestAccKubernetesService_sessionAffinityNone(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_sessionAffinity(name, "None"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity", "None"),
+				),
+			},
+			// Omitting the explicit value must not produce a diff
+			{
+				Config:   testAccKubernetesServiceConfig_basic(name),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccKubernetesServiceConfig_sessionAffinity(name, "ClientIP"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity", "ClientIP"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesService_nodePort(t *testing.T) {
 	var conf api.Service
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -405,7 +527,7 @@ func testAccCheckServicePorts(svc *api.Service, expected []api.ServicePort) reso
 }
 
 func testAccCheckKubernetesServiceDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_service" {
@@ -435,7 +557,7 @@ func testAccCheckKubernetesServiceExists(n string, obj *api.Service) resource.Te
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
@@ -476,6 +598,31 @@ resource "kubernetes_service" "test" {
 }`, name)
 }
 
+func testAccKubernetesServiceConfig_sessionAffinity(name, affinity string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		annotations {
+			TestAnnotationOne = "one"
+			TestAnnotationTwo = "two"
+		}
+		labels {
+			TestLabelOne = "one"
+			TestLabelTwo = "two"
+			TestLabelThree = "three"
+		}
+		name = "%s"
+	}
+	spec {
+		port {
+			port = 8080
+			target_port = 80
+		}
+		session_affinity = "%s"
+	}
+}`, name, affinity)
+}
+
 func testAccKubernetesServiceConfig_modified(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_service" "test" {
@@ -522,6 +669,31 @@ resource "kubernetes_service" "test" {
 }`, name, name)
 }
 
+func testAccKubernetesServiceConfig_externalTrafficPolicy(name, policy string, healthCheckNodePort int) string {
+	port := ""
+	if healthCheckNodePort > 0 {
+		port = fmt.Sprintf("health_check_node_port = %d", healthCheckNodePort)
+	}
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 8888
+			target_port = 80
+		}
+		type = "LoadBalancer"
+		external_traffic_policy = "%s"
+		%s
+	}
+}`, name, policy, port)
+}
+
 func testAccKubernetesServiceConfig_loadBalancer_modified(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_service" "test" {
@@ -24,7 +24,8 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		SchemaVersion: 1,
+		CustomizeDiff: resourceKubernetesStatefulSetCustomizeDiff,
+		SchemaVersion: 2,
 		MigrateState:  resourceKubernetesStatefulSetStateUpgrader,
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("statefulset", true),
@@ -54,9 +55,14 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 							Default:     10,
 						},
 						"selector": {
-							Type:        schema.TypeMap,
-							Description: "A label query over pods that should match the Replicas count. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Type:        schema.TypeList,
+							Description: "A label query over pods that should match the Replicas count. It must match the pod template's labels. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
 							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(false),
+							},
 						},
 						"service_name": {
 							Type:        schema.TypeString,
@@ -148,8 +154,18 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 	}
 }
 
+// resourceKubernetesStatefulSetCustomizeDiff runs the plan-time checks of the
+// pod template and of the service governing the stateful set.
+func resourceKubernetesStatefulSetCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	err := podSpecCustomizeDiff("spec.0.template.0.spec.0.")(d, meta)
+	if err != nil {
+		return err
+	}
+	return validateStatefulSetService(d, meta)
+}
+
 func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
@@ -193,7 +209,7 @@ func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{
 }
 
 func resourceKubernetesStatefulSetRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Reading statefulSet %s", name)
@@ -228,7 +244,7 @@ func resourceKubernetesStatefulSetRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceKubernetesStatefulSetUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 
@@ -266,7 +282,7 @@ func resourceKubernetesStatefulSetUpdate(d *schema.ResourceData, meta interface{
 }
 
 func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	log.Printf("[INFO] Deleting statefulSet: %#v", name)
@@ -283,6 +299,11 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 	}
 	_, err = conn.AppsV1beta1().StatefulSets(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] StatefulSet %s was already deleted", name)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -294,7 +315,7 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 	}
 
 	err = conn.AppsV1beta1().StatefulSets(namespace).Delete(name, &metav1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -305,7 +326,7 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 }
 
 func resourceKubernetesStatefulSetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetes.Clientset)
+	conn := meta.(*kubeProvider).Clientset
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
@@ -359,6 +380,13 @@ func resourceKubernetesStatefulSetStateUpgrader(
 		if err != nil {
 			return is, err
 		}
+		fallthrough
+	case 1:
+		log.Println("[INFO] Found Kubernetes StatefulSet State schema v1; migrating to v2")
+		is, err = migrateStateSelectorMapToBlock(is)
+		if err != nil {
+			return is, err
+		}
 
 	default:
 		return is, fmt.Errorf("Unexpected schema version: %d", v)
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/apis/apps/v1beta1"
 )
 
@@ -90,7 +89,7 @@ func testAccCheckKubernetesStatefulSetExists(n string, obj *v1beta1.StatefulSet)
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 		namespace, name, _ := idParts(rs.Primary.ID)
 		out, err := conn.AppsV1beta1().StatefulSets(namespace).Get(name, meta_v1.GetOptions{})
@@ -103,7 +102,7 @@ func testAccCheckKubernetesStatefulSetExists(n string, obj *v1beta1.StatefulSet)
 }
 
 func testAccCheckKubernetesStatefulSetDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+	conn := testAccProvider.Meta().(*kubeProvider).Clientset
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_stateful_set" {
@@ -133,7 +132,9 @@ resource "kubernetes_stateful_set" "test" {
   spec {
     replicas = 2
     selector {
-      app = "one"
+      match_labels {
+        app = "one"
+      }
     }
     service_name = "%s"
     template {
@@ -163,7 +164,9 @@ resource "kubernetes_stateful_set" "test" {
   spec {
     replicas = 2
     selector {
-      app = "one"
+      match_labels {
+        app = "one"
+      }
     }
     service_name = "%s"
     template {
@@ -52,7 +52,7 @@ func handlerFields() map[string]*schema.Schema {
 					"http_header": {
 						Type:        schema.TypeList,
 						Optional:    true,
-						Description: `Scheme to use for connecting to the host.`,
+						Description: `Custom headers to set in the request, in order. Repeated header names are allowed.`,
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
 								"name": {
@@ -77,6 +77,11 @@ func handlerFields() map[string]*schema.Schema {
 			Description: "TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
+					"host": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Host name to connect to, defaults to the pod IP.",
+					},
 					"port": {
 						Type:         schema.TypeString,
 						Required:     true,
@@ -256,7 +261,7 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 									Type:        schema.TypeList,
 									Optional:    true,
 									MaxItems:    1,
-									Description: "Selects a field of the pod: supports metadata.name, metadata.namespace, metadata.labels, metadata.annotations, spec.nodeName, spec.serviceAccountName, status.podIP..",
+									Description: "Selects a field of the pod: supports metadata.name, metadata.namespace, metadata.labels['<KEY>'], metadata.annotations['<KEY>'], spec.nodeName, spec.serviceAccountName, status.podIP..",
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
 											"api_version": {
@@ -277,12 +282,20 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 									Type:        schema.TypeList,
 									Optional:    true,
 									MaxItems:    1,
-									Description: "Selects a field of the pod: supports metadata.name, metadata.namespace, metadata.labels, metadata.annotations, spec.nodeName, spec.serviceAccountName, status.podIP..",
+									Description: "Selects a resource of the container: only limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.",
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
 											"container_name": {
-												Type:     schema.TypeString,
-												Optional: true,
+												Type:        schema.TypeString,
+												Optional:    true,
+												Description: "Container name: required for volumes, optional for env vars",
+											},
+											"divisor": {
+												Type:             schema.TypeString,
+												Optional:         true,
+												ValidateFunc:     validateResourceQuantity,
+												DiffSuppressFunc: suppressEquivalentResourceQuantity,
+												Description:      `Specifies the output format of the exposed resources, e.g. "1Mi" to expose memory in mebibytes. Defaults to "1".`,
 											},
 											"resource": {
 												Type:        schema.TypeString,
@@ -322,7 +335,7 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 			Type:        schema.TypeList,
 			Optional:    true,
 			ForceNew:    !isUpdatable,
-			Description: "List of environment variables to set in the container. Cannot be updated.",
+			Description: "List of sources to populate environment variables in the container, e.g. all the keys of a ConfigMap. Variables of `env` take precedence. Cannot be updated.",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"prefix": {
@@ -379,10 +392,12 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 			Description: "Docker image name. More info: http://kubernetes.io/docs/user-guide/images",
 		},
 		"image_pull_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Computed:    true,
-			Description: "Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: http://kubernetes.io/docs/user-guide/images#updating-images",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validateAttributeValueIsIn([]string{"Always", "IfNotPresent", "Never"}),
+			DiffSuppressFunc: suppressDefaultImagePullPolicy,
+			Description:      "Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: http://kubernetes.io/docs/user-guide/images#updating-images",
 		},
 		"lifecycle": {
 			Type:        schema.TypeList,
@@ -442,9 +457,10 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 						Description: "What host IP to bind the external port to.",
 					},
 					"host_port": {
-						Type:        schema.TypeInt,
-						Optional:    true,
-						Description: "Number of port to expose on the host. If specified, this must be a valid port number, 0 < x < 65536. If HostNetwork is specified, this must match ContainerPort. Most containers do not need this.",
+						Type:             schema.TypeInt,
+						Optional:         true,
+						DiffSuppressFunc: suppressDefaultHostPort,
+						Description:      "Number of port to expose on the host. If specified, this must be a valid port number, 0 < x < 65536. If HostNetwork is specified, this must match ContainerPort. Most containers do not need this.",
 					},
 					"name": {
 						Type:         schema.TypeString,
@@ -545,38 +561,43 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 func probeSchema() *schema.Resource {
 	h := handlerFields()
 	h["failure_threshold"] = &schema.Schema{
-		Type:         schema.TypeInt,
-		Optional:     true,
-		Description:  "Minimum consecutive failures for the probe to be considered failed after having succeeded.",
-		Default:      3,
-		ValidateFunc: validatePositiveInteger,
+		Type:             schema.TypeInt,
+		Optional:         true,
+		DiffSuppressFunc: suppressDefaultProbeValue(3),
+		Description:      "Minimum consecutive failures for the probe to be considered failed after having succeeded.",
+		Default:          3,
+		ValidateFunc:     validatePositiveInteger,
 	}
 	h["initial_delay_seconds"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "Number of seconds after the container has started before liveness probes are initiated. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
+		Type:             schema.TypeInt,
+		Optional:         true,
+		DiffSuppressFunc: suppressDefaultProbeValue(0),
+		Description:      "Number of seconds after the container has started before liveness probes are initiated. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
 	}
 	h["period_seconds"] = &schema.Schema{
-		Type:         schema.TypeInt,
-		Optional:     true,
-		Default:      10,
-		ValidateFunc: validatePositiveInteger,
-		Description:  "How often (in seconds) to perform the probe",
+		Type:             schema.TypeInt,
+		Optional:         true,
+		DiffSuppressFunc: suppressDefaultProbeValue(10),
+		Default:          10,
+		ValidateFunc:     validatePositiveInteger,
+		Description:      "How often (in seconds) to perform the probe",
 	}
 	h["success_threshold"] = &schema.Schema{
-		Type:         schema.TypeInt,
-		Optional:     true,
-		Default:      1,
-		ValidateFunc: validatePositiveInteger,
-		Description:  "Minimum consecutive successes for the probe to be considered successful after having failed.",
+		Type:             schema.TypeInt,
+		Optional:         true,
+		DiffSuppressFunc: suppressDefaultProbeValue(1),
+		Default:          1,
+		ValidateFunc:     validatePositiveInteger,
+		Description:      "Minimum consecutive successes for the probe to be considered successful after having failed.",
 	}
 
 	h["timeout_seconds"] = &schema.Schema{
-		Type:         schema.TypeInt,
-		Optional:     true,
-		Default:      1,
-		ValidateFunc: validatePositiveInteger,
-		Description:  "Number of seconds after which the probe times out. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
+		Type:             schema.TypeInt,
+		Optional:         true,
+		DiffSuppressFunc: suppressDefaultProbeValue(1),
+		Default:          1,
+		ValidateFunc:     validatePositiveInteger,
+		Description:      "Number of seconds after which the probe times out. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
 	}
 	return &schema.Resource{
 		Schema: h,
@@ -625,15 +646,17 @@ func securityContextSchema() *schema.Resource {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"add": {
-						Type:        schema.TypeList,
+						Type:        schema.TypeSet,
 						Optional:    true,
 						Elem:        &schema.Schema{Type: schema.TypeString},
+						Set:         schema.HashString,
 						Description: "Added capabilities",
 					},
 					"drop": {
-						Type:        schema.TypeList,
+						Type:        schema.TypeSet,
 						Optional:    true,
 						Elem:        &schema.Schema{Type: schema.TypeString},
+						Set:         schema.HashString,
 						Description: "Removed capabilities",
 					},
 				},
@@ -15,9 +15,9 @@ func jobSpecFields() map[string]*schema.Schema {
 		"completions": {
 			Type:         schema.TypeInt,
 			Optional:     true,
-			Default:      1,
+			Computed:     true,
 			ValidateFunc: validatePositiveInteger,
-			Description:  "Specifies the desired number of successfully finished pods the job should be run with. Setting to nil means that the success of any pod signals the success of all pods, and allows parallelism to have any positive value. Setting to 1 means that parallelism is limited to 1 and the success of that pod signals the success of the job. More info: https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/",
+			Description:  "Specifies the desired number of successfully finished pods the job should be run with. Leaving it unset means that the success of any pod signals the success of all pods, and allows parallelism to have any positive value. Setting to 1 means that parallelism is limited to 1 and the success of that pod signals the success of the job. More info: https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/",
 		},
 		"manual_selector": {
 			Type:        schema.TypeBool,
@@ -34,7 +34,7 @@ func jobSpecFields() map[string]*schema.Schema {
 		},
 		"selector": {
 			Type:        schema.TypeList,
-			Description: "A label query over volumes to consider for binding.",
+			Description: "A label query over the pods of the job. Only set along with `manual_selector`, Kubernetes generates it otherwise. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
 			Optional:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -86,6 +86,13 @@ func jobSpecFields() map[string]*schema.Schema {
 				Schema: podSpecFields(false),
 			},
 		},
+		"wait_for_completion": {
+			Type:             schema.TypeBool,
+			Optional:         true,
+			Default:          true,
+			DiffSuppressFunc: suppressAfterCreation,
+			Description:      "Wait for the job to complete when creating it, failing if the job fails. Defaults to true. Only used on creation.",
+		},
 	}
 
 	return s
@@ -116,5 +116,14 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 		},
 	}
 
+	if !pvcTemplate {
+		s["delete_released_volume"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Description: "Whether to delete the bound volume once it's released by the claim, when its reclaim policy is `Retain`. Otherwise the volume is left in place without waiting for it to be released",
+			Optional:    true,
+			Default:     false,
+		}
+	}
+
 	return s
 }
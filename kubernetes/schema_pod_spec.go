@@ -12,6 +12,15 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			ValidateFunc: validatePositiveInteger,
 			Description:  "Optional duration in seconds the pod may be active on the node relative to StartTime before the system will actively try to mark it failed and kill associated containers. Value must be a positive integer.",
 		},
+		"affinity": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Scheduling constraints of the pod: node affinity, and pod affinity and anti-affinity, e.g. to spread replicas across zones.",
+			Elem: &schema.Resource{
+				Schema: affinityFields(),
+			},
+		},
 		"container": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -21,10 +30,11 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			},
 		},
 		"dns_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "ClusterFirst",
-			Description: "Set DNS policy for containers within the pod. One of 'ClusterFirst' or 'Default'. Defaults to 'ClusterFirst'.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "ClusterFirst",
+			Description:  "Set DNS policy for containers within the pod. One of 'ClusterFirst', 'ClusterFirstWithHostNet' or 'Default'. Pods with `host_network` need 'ClusterFirstWithHostNet' to use the cluster DNS. Defaults to 'ClusterFirst'.",
+			ValidateFunc: validateDNSPolicy,
 		},
 		"host_ipc": {
 			Type:        schema.TypeBool,
@@ -158,6 +168,14 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			ValidateFunc: validateTerminationGracePeriodSeconds,
 			Description:  "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process.",
 		},
+		"toleration": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "If specified, the pod's toleration. The pod tolerates any taint that matches the triple <key,value,effect> using the matching operator <operator>.",
+			Elem: &schema.Resource{
+				Schema: tolerationFields(),
+			},
+		},
 
 		"volume": {
 			Type:        schema.TypeList,
@@ -184,6 +202,40 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 	return s
 }
 
+func tolerationFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"effect": {
+			Type:         schema.TypeString,
+			Description:  "Indicates the taint effect to match. Empty means match all taint effects. When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.",
+			Optional:     true,
+			ValidateFunc: validateAttributeValueIsIn([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}),
+		},
+		"key": {
+			Type:        schema.TypeString,
+			Description: "The taint key that the toleration applies to. Empty means match all taint keys. If the key is empty, operator must be Exists; this combination means to match all values and all keys.",
+			Optional:    true,
+		},
+		"operator": {
+			Type:         schema.TypeString,
+			Description:  "Represents a key's relationship to the value. Valid operators are Exists and Equal. Exists is equivalent to wildcard for value, so that a pod can tolerate all taints of a particular category.",
+			Optional:     true,
+			Default:      "Equal",
+			ValidateFunc: validateAttributeValueIsIn([]string{"Exists", "Equal"}),
+		},
+		"toleration_seconds": {
+			Type:         schema.TypeString,
+			Description:  "The period of time the toleration (which must be of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default, it is not set, which means tolerate the taint forever. Zero evicts the pod immediately. A string, so that zero can be told apart from not set.",
+			Optional:     true,
+			ValidateFunc: validateNonNegativeIntegerString,
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Description: "The taint value the toleration matches to. If the operator is Exists, the value should be empty, otherwise just a regular string.",
+			Optional:    true,
+		},
+	}
+}
+
 func volumeSchema() *schema.Resource {
 	v := commonVolumeSources()
 
@@ -223,6 +275,7 @@ func volumeSchema() *schema.Resource {
 					Type:         schema.TypeInt,
 					Description:  "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
 					Optional:     true,
+					Computed:     true,
 					ValidateFunc: validateModeBits,
 				},
 				"name": {
@@ -230,6 +283,11 @@ func volumeSchema() *schema.Resource {
 					Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
 					Optional:    true,
 				},
+				"optional": {
+					Type:        schema.TypeBool,
+					Description: "Optional: Specify whether the ConfigMap or it's keys must be defined. If true, the pod starts even if the ConfigMap or some of the listed keys are missing.",
+					Optional:    true,
+				},
 			},
 		},
 	}
@@ -271,6 +329,7 @@ func volumeSchema() *schema.Resource {
 					Type:        schema.TypeInt,
 					Description: "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
 					Optional:    true,
+					Computed:    true,
 				},
 				"items": {
 					Type:        schema.TypeList,
@@ -321,9 +380,17 @@ func volumeSchema() *schema.Resource {
 											Type:     schema.TypeString,
 											Required: true,
 										},
+										"divisor": {
+											Type:             schema.TypeString,
+											Optional:         true,
+											ValidateFunc:     validateResourceQuantity,
+											DiffSuppressFunc: suppressEquivalentResourceQuantity,
+											Description:      `Specifies the output format of the exposed resources, e.g. "1Mi" to expose memory in mebibytes. Defaults to "1".`,
+										},
 										"quantity": {
-											Type:     schema.TypeString,
-											Optional: true,
+											Type:       schema.TypeString,
+											Optional:   true,
+											Deprecated: "quantity never had any effect, use divisor to set the output format of the exposed resources",
 										},
 										"resource": {
 											Type:        schema.TypeString,
@@ -0,0 +1,218 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Flatteners
+
+func flattenAffinity(in *v1.Affinity) []interface{} {
+	att := make(map[string]interface{})
+	if in.NodeAffinity != nil {
+		att["node_affinity"] = flattenNodeAffinity(in.NodeAffinity)
+	}
+	if in.PodAffinity != nil {
+		att["pod_affinity"] = flattenPodAffinityRules(in.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, in.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if in.PodAntiAffinity != nil {
+		att["pod_anti_affinity"] = flattenPodAffinityRules(in.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, in.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	return []interface{}{att}
+}
+
+func flattenNodeAffinity(in *v1.NodeAffinity) []interface{} {
+	att := make(map[string]interface{})
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms := make([]interface{}, len(in.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms))
+		for i, t := range in.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			terms[i] = flattenNodeSelectorTerm(t)
+		}
+		att["required_during_scheduling_ignored_during_execution"] = []interface{}{map[string]interface{}{
+			"node_selector_term": terms,
+		}}
+	}
+	if len(in.PreferredDuringSchedulingIgnoredDuringExecution) > 0 {
+		preferred := make([]interface{}, len(in.PreferredDuringSchedulingIgnoredDuringExecution))
+		for i, p := range in.PreferredDuringSchedulingIgnoredDuringExecution {
+			preferred[i] = map[string]interface{}{
+				"weight":     int(p.Weight),
+				"preference": []interface{}{flattenNodeSelectorTerm(p.Preference)},
+			}
+		}
+		att["preferred_during_scheduling_ignored_during_execution"] = preferred
+	}
+	return []interface{}{att}
+}
+
+func flattenNodeSelectorTerm(in v1.NodeSelectorTerm) map[string]interface{} {
+	att := make(map[string]interface{})
+	if len(in.MatchExpressions) > 0 {
+		expressions := make([]interface{}, len(in.MatchExpressions))
+		for i, e := range in.MatchExpressions {
+			expressions[i] = map[string]interface{}{
+				"key":      e.Key,
+				"operator": string(e.Operator),
+				"values":   newStringSet(schema.HashString, e.Values),
+			}
+		}
+		att["match_expressions"] = expressions
+	}
+	return att
+}
+
+func flattenPodAffinityRules(required []v1.PodAffinityTerm, preferred []v1.WeightedPodAffinityTerm) []interface{} {
+	att := make(map[string]interface{})
+	if len(required) > 0 {
+		terms := make([]interface{}, len(required))
+		for i, t := range required {
+			terms[i] = flattenPodAffinityTerm(t)
+		}
+		att["required_during_scheduling_ignored_during_execution"] = terms
+	}
+	if len(preferred) > 0 {
+		terms := make([]interface{}, len(preferred))
+		for i, t := range preferred {
+			terms[i] = map[string]interface{}{
+				"weight":            int(t.Weight),
+				"pod_affinity_term": []interface{}{flattenPodAffinityTerm(t.PodAffinityTerm)},
+			}
+		}
+		att["preferred_during_scheduling_ignored_during_execution"] = terms
+	}
+	return []interface{}{att}
+}
+
+func flattenPodAffinityTerm(in v1.PodAffinityTerm) map[string]interface{} {
+	att := make(map[string]interface{})
+	if in.LabelSelector != nil {
+		att["label_selector"] = flattenLabelSelector(in.LabelSelector)
+	}
+	if len(in.Namespaces) > 0 {
+		att["namespaces"] = newStringSet(schema.HashString, in.Namespaces)
+	}
+	att["topology_key"] = in.TopologyKey
+	return att
+}
+
+// Expanders
+
+func expandAffinity(l []interface{}) *v1.Affinity {
+	if len(l) == 0 || l[0] == nil {
+		return &v1.Affinity{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.Affinity{}
+	if v, ok := in["node_affinity"].([]interface{}); ok && len(v) > 0 {
+		obj.NodeAffinity = expandNodeAffinity(v)
+	}
+	if v, ok := in["pod_affinity"].([]interface{}); ok && len(v) > 0 {
+		required, preferred := expandPodAffinityRules(v)
+		obj.PodAffinity = &v1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	if v, ok := in["pod_anti_affinity"].([]interface{}); ok && len(v) > 0 {
+		required, preferred := expandPodAffinityRules(v)
+		obj.PodAntiAffinity = &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	return obj
+}
+
+func expandNodeAffinity(l []interface{}) *v1.NodeAffinity {
+	obj := &v1.NodeAffinity{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["required_during_scheduling_ignored_during_execution"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		terms := v[0].(map[string]interface{})["node_selector_term"].([]interface{})
+		selector := &v1.NodeSelector{NodeSelectorTerms: make([]v1.NodeSelectorTerm, len(terms))}
+		for i, t := range terms {
+			selector.NodeSelectorTerms[i] = expandNodeSelectorTerm(t)
+		}
+		obj.RequiredDuringSchedulingIgnoredDuringExecution = selector
+	}
+	if v, ok := in["preferred_during_scheduling_ignored_during_execution"].([]interface{}); ok && len(v) > 0 {
+		obj.PreferredDuringSchedulingIgnoredDuringExecution = make([]v1.PreferredSchedulingTerm, len(v))
+		for i, p := range v {
+			m := p.(map[string]interface{})
+			term := v1.PreferredSchedulingTerm{Weight: int32(m["weight"].(int))}
+			if pref, ok := m["preference"].([]interface{}); ok && len(pref) > 0 {
+				term.Preference = expandNodeSelectorTerm(pref[0])
+			}
+			obj.PreferredDuringSchedulingIgnoredDuringExecution[i] = term
+		}
+	}
+	return obj
+}
+
+func expandNodeSelectorTerm(v interface{}) v1.NodeSelectorTerm {
+	obj := v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{}}
+	if v == nil {
+		return obj
+	}
+	in := v.(map[string]interface{})
+	if l, ok := in["match_expressions"].([]interface{}); ok {
+		for _, e := range l {
+			m := e.(map[string]interface{})
+			r := v1.NodeSelectorRequirement{
+				Key:      m["key"].(string),
+				Operator: v1.NodeSelectorOperator(m["operator"].(string)),
+			}
+			if values, ok := m["values"].(*schema.Set); ok && values.Len() > 0 {
+				r.Values = sliceOfString(values.List())
+			}
+			obj.MatchExpressions = append(obj.MatchExpressions, r)
+		}
+	}
+	return obj
+}
+
+func expandPodAffinityRules(l []interface{}) ([]v1.PodAffinityTerm, []v1.WeightedPodAffinityTerm) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	var required []v1.PodAffinityTerm
+	if v, ok := in["required_during_scheduling_ignored_during_execution"].([]interface{}); ok {
+		for _, t := range v {
+			required = append(required, expandPodAffinityTerm(t))
+		}
+	}
+	var preferred []v1.WeightedPodAffinityTerm
+	if v, ok := in["preferred_during_scheduling_ignored_during_execution"].([]interface{}); ok {
+		for _, t := range v {
+			m := t.(map[string]interface{})
+			term := v1.WeightedPodAffinityTerm{Weight: int32(m["weight"].(int))}
+			if pat, ok := m["pod_affinity_term"].([]interface{}); ok && len(pat) > 0 {
+				term.PodAffinityTerm = expandPodAffinityTerm(pat[0])
+			}
+			preferred = append(preferred, term)
+		}
+	}
+	return required, preferred
+}
+
+func expandPodAffinityTerm(v interface{}) v1.PodAffinityTerm {
+	obj := v1.PodAffinityTerm{}
+	if v == nil {
+		return obj
+	}
+	in := v.(map[string]interface{})
+	if l, ok := in["label_selector"].([]interface{}); ok && len(l) > 0 {
+		obj.LabelSelector = expandLabelSelector(l)
+	}
+	if s, ok := in["namespaces"].(*schema.Set); ok && s.Len() > 0 {
+		obj.Namespaces = sliceOfString(s.List())
+	}
+	if k, ok := in["topology_key"].(string); ok {
+		obj.TopologyKey = k
+	}
+	return obj
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestExpandFlattenAffinity(t *testing.T) {
+	affinity := &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{
+					{MatchExpressions: []v1.NodeSelectorRequirement{
+						{Key: "beta.kubernetes.io/instance-type", Operator: v1.NodeSelectorOpIn, Values: []string{"m4.large"}},
+					}},
+					{MatchExpressions: []v1.NodeSelectorRequirement{
+						{Key: "dedicated", Operator: v1.NodeSelectorOpExists},
+					}},
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+				{
+					Weight: 10,
+					Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+						{Key: "cores", Operator: v1.NodeSelectorOpGt, Values: []string{"4"}},
+					}},
+				},
+			},
+		},
+		PodAntiAffinity: &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+				{
+					LabelSelector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: v1.PodAffinityTerm{
+						LabelSelector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						Namespaces:    []string{"default"},
+						TopologyKey:   "failure-domain.beta.kubernetes.io/zone",
+					},
+				},
+				{
+					Weight: 50,
+					PodAffinityTerm: v1.PodAffinityTerm{
+						LabelSelector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						TopologyKey:   "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
+	}
+
+	// Round-trip through the schema, which normalizes the flattened label selectors
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"affinity": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: affinityFields()},
+		},
+	}, map[string]interface{}{})
+	if err := d.Set("affinity", flattenAffinity(affinity)); err != nil {
+		t.Fatal(err)
+	}
+	out := expandAffinity(d.Get("affinity").([]interface{}))
+	if !reflect.DeepEqual(out, affinity) {
+		t.Fatalf("Unexpected affinity.\nExpected: %#v\nGiven:    %#v", affinity, out)
+	}
+
+	// The terms keep their order, so changing one of them yields a clean diff
+	flattened := flattenAffinity(affinity)[0].(map[string]interface{})
+	preferred := flattened["pod_anti_affinity"].([]interface{})[0].(map[string]interface{})["preferred_during_scheduling_ignored_during_execution"].([]interface{})
+	for i, expected := range []string{"failure-domain.beta.kubernetes.io/zone", "kubernetes.io/hostname"} {
+		term := preferred[i].(map[string]interface{})["pod_affinity_term"].([]interface{})[0].(map[string]interface{})
+		if term["topology_key"] != expected {
+			t.Fatalf("Expected term %d to have topology key %q, given %q", i, expected, term["topology_key"])
+		}
+	}
+}
+
+func TestExpandAffinity_empty(t *testing.T) {
+	out := expandAffinity([]interface{}{map[string]interface{}{
+		"node_affinity":     []interface{}{},
+		"pod_affinity":      []interface{}{},
+		"pod_anti_affinity": []interface{}{},
+	}})
+	if !reflect.DeepEqual(out, &v1.Affinity{}) {
+		t.Fatalf("Expected an empty affinity, given %#v", out)
+	}
+
+	// Values are sets in the configuration
+	term := expandNodeSelectorTerm(map[string]interface{}{
+		"match_expressions": []interface{}{map[string]interface{}{
+			"key":      "dedicated",
+			"operator": "Exists",
+			"values":   schema.NewSet(schema.HashString, []interface{}{}),
+		}},
+	})
+	expected := v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+		{Key: "dedicated", Operator: v1.NodeSelectorOpExists},
+	}}
+	if !reflect.DeepEqual(term, expected) {
+		t.Fatalf("Unexpected term.\nExpected: %#v\nGiven:    %#v", expected, term)
+	}
+}
@@ -1,12 +1,14 @@
 package kubernetes
 
 import (
+	"sort"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/client-go/pkg/api/v1"
 	batchv1 "k8s.io/client-go/pkg/apis/batch/v1"
 )
 
-func flattenJobSpec(in batchv1.JobSpec) ([]interface{}, error) {
+func flattenJobSpec(in batchv1.JobSpec, d *schema.ResourceData) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
 	if in.ActiveDeadlineSeconds != nil {
@@ -25,7 +27,8 @@ func flattenJobSpec(in batchv1.JobSpec) ([]interface{}, error) {
 		att["parallelism"] = *in.Parallelism
 	}
 
-	if in.Selector != nil {
+	// The selector generated by the controller isn't part of the configuration
+	if in.Selector != nil && in.ManualSelector != nil && *in.ManualSelector {
 		att["selector"] = flattenLabelSelector(in.Selector)
 	}
 
@@ -35,6 +38,12 @@ func flattenJobSpec(in batchv1.JobSpec) ([]interface{}, error) {
 	}
 	att["template"] = podSpec
 
+	// Not part of the job, kept from the configuration
+	att["wait_for_completion"] = true
+	if v, ok := d.GetOkExists("spec.0.wait_for_completion"); ok {
+		att["wait_for_completion"] = v
+	}
+
 	return []interface{}{att}, nil
 }
 
@@ -93,3 +102,24 @@ func patchJobSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOpera
 
 	return ops, nil
 }
+
+// flattenJobExitCodes returns the exit codes of the terminated containers of
+// the most recently created pod that has any, so codes of retried pods aren't
+// mixed with the ones of their replacement.
+func flattenJobExitCodes(pods []v1.Pod) map[string]interface{} {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.Time.After(pods[j].CreationTimestamp.Time)
+	})
+	codes := make(map[string]interface{})
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil {
+				codes[status.Name] = int(status.State.Terminated.ExitCode)
+			}
+		}
+		if len(codes) > 0 {
+			break
+		}
+	}
+	return codes
+}
@@ -0,0 +1,122 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+	batchv1 "k8s.io/client-go/pkg/apis/batch/v1"
+)
+
+func TestFlattenJobExitCodes(t *testing.T) {
+	now := time.Now()
+	terminated := func(name string, code int32) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			Name:  name,
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: code}},
+		}
+	}
+	running := func(name string) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			Name:  name,
+			State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+		}
+	}
+	pod := func(created time.Time, statuses ...v1.ContainerStatus) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{CreationTimestamp: meta_v1.NewTime(created)},
+			Status:     v1.PodStatus{ContainerStatuses: statuses},
+		}
+	}
+
+	cases := []struct {
+		Name     string
+		Pods     []v1.Pod
+		Expected map[string]interface{}
+	}{
+		{
+			"no pods",
+			[]v1.Pod{},
+			map[string]interface{}{},
+		},
+		{
+			"still running",
+			[]v1.Pod{pod(now, running("main"))},
+			map[string]interface{}{},
+		},
+		{
+			"retried pod",
+			[]v1.Pod{
+				pod(now, terminated("main", 0), terminated("sidecar", 0)),
+				pod(now.Add(-time.Minute), terminated("main", 1), terminated("sidecar", 137)),
+			},
+			map[string]interface{}{"main": 0, "sidecar": 0},
+		},
+		{
+			"latest pod still running",
+			[]v1.Pod{
+				pod(now.Add(-time.Minute), terminated("main", 2)),
+				pod(now, running("main")),
+			},
+			map[string]interface{}{"main": 2},
+		},
+		{
+			"latest pod partly terminated",
+			[]v1.Pod{
+				pod(now.Add(-time.Minute), terminated("main", 1), terminated("sidecar", 137)),
+				pod(now, terminated("main", 0), running("sidecar")),
+			},
+			map[string]interface{}{"main": 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := flattenJobExitCodes(tc.Pods)
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected exit codes.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestFlattenJobSpec_selector(t *testing.T) {
+	selector := &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "migrate"}}
+
+	cases := []struct {
+		Name           string
+		ManualSelector *bool
+		Expected       interface{}
+	}{
+		{"generated", nil, nil},
+		{"generated with manual selector off", ptrToBool(false), nil},
+		{"manual", ptrToBool(true), flattenLabelSelector(selector)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceKubernetesJob().Schema, map[string]interface{}{})
+			spec := batchv1.JobSpec{
+				ManualSelector: tc.ManualSelector,
+				Selector:       selector,
+			}
+			out, err := flattenJobSpec(spec, d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			given, ok := out[0].(map[string]interface{})["selector"]
+			if tc.Expected == nil {
+				if ok {
+					t.Fatalf("Expected no selector, given %#v", given)
+				}
+				return
+			}
+			if !reflect.DeepEqual(given, tc.Expected) {
+				t.Fatalf("Unexpected selector.\nExpected: %#v\nGiven:    %#v", tc.Expected, given)
+			}
+		})
+	}
+}
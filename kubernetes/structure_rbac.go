@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	rbacv1beta1 "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+func expandPolicyRules(in []interface{}) []rbacv1beta1.PolicyRule {
+	rules := make([]rbacv1beta1.PolicyRule, 0, len(in))
+	for _, r := range in {
+		if r == nil {
+			continue
+		}
+		m := r.(map[string]interface{})
+		rule := rbacv1beta1.PolicyRule{}
+		if v, ok := m["api_groups"].([]interface{}); ok && len(v) > 0 {
+			rule.APIGroups = expandStringSlice(v)
+		}
+		if v, ok := m["non_resource_urls"].([]interface{}); ok && len(v) > 0 {
+			rule.NonResourceURLs = expandStringSlice(v)
+		}
+		if v, ok := m["resource_names"].([]interface{}); ok && len(v) > 0 {
+			rule.ResourceNames = expandStringSlice(v)
+		}
+		if v, ok := m["resources"].([]interface{}); ok && len(v) > 0 {
+			rule.Resources = expandStringSlice(v)
+		}
+		if v, ok := m["verbs"].([]interface{}); ok {
+			rule.Verbs = expandStringSlice(v)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func flattenPolicyRules(in []rbacv1beta1.PolicyRule) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, r := range in {
+		m := make(map[string]interface{})
+		if len(r.APIGroups) > 0 {
+			m["api_groups"] = r.APIGroups
+		}
+		if len(r.NonResourceURLs) > 0 {
+			m["non_resource_urls"] = r.NonResourceURLs
+		}
+		if len(r.ResourceNames) > 0 {
+			m["resource_names"] = r.ResourceNames
+		}
+		if len(r.Resources) > 0 {
+			m["resources"] = r.Resources
+		}
+		m["verbs"] = r.Verbs
+		att[i] = m
+	}
+	return att
+}
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"fmt"
+
 	gversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -58,6 +60,10 @@ func flattenServiceSpec(in v1.ServiceSpec) []interface{} {
 	if in.ExternalName != "" {
 		att["external_name"] = in.ExternalName
 	}
+	if in.ExternalTrafficPolicy != "" {
+		att["external_traffic_policy"] = string(in.ExternalTrafficPolicy)
+	}
+	att["health_check_node_port"] = int(in.HealthCheckNodePort)
 	return []interface{}{att}
 }
 
@@ -76,6 +82,26 @@ func flattenLoadBalancerIngress(in []v1.LoadBalancerIngress) []interface{} {
 
 // Expanders
 
+func expandLoadBalancerIngress(l []interface{}) ([]v1.LoadBalancerIngress, error) {
+	obj := make([]v1.LoadBalancerIngress, len(l), len(l))
+	for i, n := range l {
+		cfg, _ := n.(map[string]interface{})
+		if cfg == nil {
+			cfg = map[string]interface{}{}
+		}
+		ip, _ := cfg["ip"].(string)
+		hostname, _ := cfg["hostname"].(string)
+		if ip == "" && hostname == "" {
+			return nil, fmt.Errorf("load_balancer_ingress.%d: one of `ip` or `hostname` must be set", i)
+		}
+		obj[i] = v1.LoadBalancerIngress{
+			IP:       ip,
+			Hostname: hostname,
+		}
+	}
+	return obj, nil
+}
+
 func expandIntOrString(in int) intstr.IntOrString {
 	return intstr.FromInt(in)
 }
@@ -138,6 +164,12 @@ func expandServiceSpec(l []interface{}) v1.ServiceSpec {
 	if v, ok := in["external_name"].(string); ok {
 		obj.ExternalName = v
 	}
+	if v, ok := in["external_traffic_policy"].(string); ok {
+		obj.ExternalTrafficPolicy = v1.ServiceExternalTrafficPolicyType(v)
+	}
+	if v, ok := in["health_check_node_port"].(int); ok {
+		obj.HealthCheckNodePort = int32(v)
+	}
 	return obj
 }
 
@@ -206,5 +238,26 @@ func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, v *v
 			Value: d.Get(keyPrefix + "external_name").(string),
 		})
 	}
+	if d.HasChange(keyPrefix + "external_traffic_policy") {
+		// The policy is absent on ClusterIP services, so it can't be replaced
+		policy := d.Get(keyPrefix + "external_traffic_policy").(string)
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "externalTrafficPolicy",
+			Value: policy,
+		})
+		if policy != string(v1.ServiceExternalTrafficPolicyTypeLocal) {
+			// Only Local services may have a health check port
+			ops = append(ops, &AddOperation{
+				Path:  pathPrefix + "healthCheckNodePort",
+				Value: 0,
+			})
+		}
+	}
+	if d.HasChange(keyPrefix+"health_check_node_port") && d.Get(keyPrefix+"external_traffic_policy").(string) == string(v1.ServiceExternalTrafficPolicyTypeLocal) {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "healthCheckNodePort",
+			Value: d.Get(keyPrefix + "health_check_node_port").(int),
+		})
+	}
 	return ops, nil
 }
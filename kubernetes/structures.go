@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/mitchellh/copystructure"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -197,6 +199,18 @@ func ptrToInt64(i int64) *int64 {
 	return &i
 }
 
+// expandIntOrPercent expands a value checked by validateIntOrPercent, e.g. a
+// max_surge of "1" or "25%".
+func expandIntOrPercent(v string) *intstr.IntOrString {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		out := intstr.FromString(v)
+		return &out
+	}
+	out := intstr.FromInt(i)
+	return &out
+}
+
 func sliceOfString(slice []interface{}) []string {
 	result := make([]string, len(slice), len(slice))
 	for i, s := range slice {
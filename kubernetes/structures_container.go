@@ -1,18 +1,21 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/pkg/api/v1"
 )
 
-func flattenCapability(in []v1.Capability) []string {
+func flattenCapability(in []v1.Capability) *schema.Set {
 	att := make([]string, len(in), len(in))
 	for i, v := range in {
 		att[i] = string(v)
 	}
-	return att
+	return newStringSet(schema.HashString, att)
 }
 
 func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
@@ -112,7 +115,7 @@ func flattenHTTPGet(in *v1.HTTPGetAction) []interface{} {
 		att["path"] = in.Path
 	}
 	att["port"] = in.Port.String()
-	att["scheme"] = in.Scheme
+	att["scheme"] = string(in.Scheme)
 	if len(in.HTTPHeaders) > 0 {
 		att["http_header"] = flattenHTTPHeader(in.HTTPHeaders)
 	}
@@ -122,6 +125,9 @@ func flattenHTTPGet(in *v1.HTTPGetAction) []interface{} {
 
 func flattenTCPSocket(in *v1.TCPSocketAction) []interface{} {
 	att := make(map[string]interface{})
+	if in.Host != "" {
+		att["host"] = in.Host
+	}
 	att["port"] = in.Port.String()
 	return []interface{}{att}
 }
@@ -202,6 +208,9 @@ func flattenResourceFieldSelector(in *v1.ResourceFieldSelector) []interface{} {
 	if in.Resource != "" {
 		att["resource"] = in.Resource
 	}
+	if !in.Divisor.IsZero() {
+		att["divisor"] = in.Divisor.String()
+	}
 	return []interface{}{att}
 }
 
@@ -242,7 +251,9 @@ func flattenConfigMapEnvSource(in *v1.ConfigMapEnvSource) []interface{} {
 		att["name"] = in.Name
 	}
 
-	att["optional"] = *in.Optional
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
 
 	return []interface{}{att}
 }
@@ -254,7 +265,9 @@ func flattenSecretEnvSource(in *v1.SecretEnvSource) []interface{} {
 		att["name"] = in.Name
 	}
 
-	att["optional"] = *in.Optional
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
 
 	return []interface{}{att}
 }
@@ -576,11 +589,11 @@ func expandSecurityCapabilities(l []interface{}) *v1.Capabilities {
 	}
 	in := l[0].(map[string]interface{})
 	obj := v1.Capabilities{}
-	if v, ok := in["add"].([]interface{}); ok {
-		obj.Add = expandCapabilitySlice(v)
+	if v, ok := in["add"].(*schema.Set); ok {
+		obj.Add = expandCapabilitySlice(v.List())
 	}
-	if v, ok := in["drop"].([]interface{}); ok {
-		obj.Drop = expandCapabilitySlice(v)
+	if v, ok := in["drop"].(*schema.Set); ok {
+		obj.Drop = expandCapabilitySlice(v.List())
 	}
 	return &obj
 }
@@ -591,6 +604,9 @@ func expandTCPSocket(l []interface{}) *v1.TCPSocketAction {
 	}
 	in := l[0].(map[string]interface{})
 	obj := v1.TCPSocketAction{}
+	if v, ok := in["host"].(string); ok && len(v) > 0 {
+		obj.Host = v
+	}
 	if v, ok := in["port"].(string); ok && len(v) > 0 {
 		obj.Port = expandPort(v)
 	}
@@ -835,6 +851,13 @@ func expandResourceFieldRef(r []interface{}) (*v1.ResourceFieldSelector, error)
 	if v, ok := in["resource"].(string); ok {
 		obj.Resource = v
 	}
+	if v, ok := in["divisor"].(string); ok && v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return obj, fmt.Errorf("Failed to parse divisor %q: %s", v, err)
+		}
+		obj.Divisor = q
+	}
 	return obj, nil
 }
 func expandSecretKeyRef(r []interface{}) (*v1.SecretKeySelector, error) {
@@ -0,0 +1,262 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestFlattenSecurityCapabilities(t *testing.T) {
+	cases := []struct {
+		Input        *v1.Capabilities
+		ExpectedAdd  []interface{}
+		ExpectedDrop []interface{}
+	}{
+		{
+			&v1.Capabilities{
+				Add:  []v1.Capability{"NET_BIND_SERVICE"},
+				Drop: []v1.Capability{"ALL"},
+			},
+			[]interface{}{"NET_BIND_SERVICE"},
+			[]interface{}{"ALL"},
+		},
+		{
+			&v1.Capabilities{
+				Add: []v1.Capability{"SYS_TIME", "NET_ADMIN"},
+			},
+			[]interface{}{"NET_ADMIN", "SYS_TIME"},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		out := flattenSecurityCapabilities(tc.Input)[0].(map[string]interface{})
+		add := schema.NewSet(schema.HashString, tc.ExpectedAdd)
+		if !add.Equal(out["add"]) {
+			t.Fatalf("Unexpected added capabilities.\nExpected: %#v\nGiven:    %#v", add.List(), out["add"])
+		}
+		if tc.ExpectedDrop == nil {
+			if _, ok := out["drop"]; ok {
+				t.Fatalf("Expected no dropped capabilities, given: %#v", out["drop"])
+			}
+			continue
+		}
+		drop := schema.NewSet(schema.HashString, tc.ExpectedDrop)
+		if !drop.Equal(out["drop"]) {
+			t.Fatalf("Unexpected dropped capabilities.\nExpected: %#v\nGiven:    %#v", drop.List(), out["drop"])
+		}
+	}
+}
+
+func TestExpandSecurityCapabilities_dropAllAddOne(t *testing.T) {
+	in := &v1.Capabilities{
+		Add:  []v1.Capability{"NET_BIND_SERVICE"},
+		Drop: []v1.Capability{"ALL"},
+	}
+
+	out := expandSecurityCapabilities(flattenSecurityCapabilities(in))
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Capabilities didn't round-trip.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
+
+func TestExpandFlattenProbe_thresholds(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"failure_threshold":     5,
+		"initial_delay_seconds": 15,
+		"period_seconds":        20,
+		"success_threshold":     2,
+		"timeout_seconds":       3,
+	}}
+	expected := &v1.Probe{
+		FailureThreshold:    5,
+		InitialDelaySeconds: 15,
+		PeriodSeconds:       20,
+		SuccessThreshold:    2,
+		TimeoutSeconds:      3,
+	}
+
+	probe := expandProbe(in)
+	if !reflect.DeepEqual(probe, expected) {
+		t.Fatalf("Unexpected probe.\nExpected: %#v\nGiven:    %#v", expected, probe)
+	}
+	out := flattenProbe(probe)[0].(map[string]interface{})
+	for k, v := range in[0].(map[string]interface{}) {
+		if int(out[k].(int32)) != v.(int) {
+			t.Fatalf("Expected %s to round-trip as %d, given %v", k, v, out[k])
+		}
+	}
+}
+
+func TestExpandFlattenHTTPHeaders_order(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{"name": "X-Forwarded-For", "value": "10.0.0.1"},
+		map[string]interface{}{"name": "Accept", "value": "application/json"},
+		map[string]interface{}{"name": "X-Forwarded-For", "value": "10.0.0.2"},
+	}
+	expected := []v1.HTTPHeader{
+		{Name: "X-Forwarded-For", Value: "10.0.0.1"},
+		{Name: "Accept", Value: "application/json"},
+		{Name: "X-Forwarded-For", Value: "10.0.0.2"},
+	}
+
+	headers := expandHTTPHeaders(in)
+	if !reflect.DeepEqual(headers, expected) {
+		t.Fatalf("Unexpected headers.\nExpected: %#v\nGiven:    %#v", expected, headers)
+	}
+	out := flattenHTTPHeader(headers)
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Unexpected flattened headers.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
+
+func TestExpandFlattenLifeCycle_ports(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"pre_stop": []interface{}{map[string]interface{}{
+			"http_get": []interface{}{map[string]interface{}{
+				"path":   "/drain",
+				"port":   "http",
+				"scheme": "HTTP",
+			}},
+		}},
+		"post_start": []interface{}{map[string]interface{}{
+			"tcp_socket": []interface{}{map[string]interface{}{
+				"host": "cache.internal",
+				"port": "6379",
+			}},
+		}},
+	}}
+	expected := &v1.Lifecycle{
+		PreStop: &v1.Handler{HTTPGet: &v1.HTTPGetAction{
+			Path:   "/drain",
+			Port:   intstr.FromString("http"),
+			Scheme: v1.URISchemeHTTP,
+		}},
+		PostStart: &v1.Handler{TCPSocket: &v1.TCPSocketAction{
+			Host: "cache.internal",
+			Port: intstr.FromInt(6379),
+		}},
+	}
+
+	lifecycle := expandLifeCycle(in)
+	if !reflect.DeepEqual(lifecycle, expected) {
+		t.Fatalf("Unexpected lifecycle.\nExpected: %#v\nGiven:    %#v", expected, lifecycle)
+	}
+	out := flattenLifeCycle(lifecycle)
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Unexpected flattened lifecycle.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
+
+func TestExpandFlattenContainerEnvFrom_order(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"prefix":         "APP_",
+			"config_map_ref": []interface{}{map[string]interface{}{"name": "app-config", "optional": false}},
+		},
+		map[string]interface{}{
+			"secret_ref": []interface{}{map[string]interface{}{"name": "app-secrets", "optional": true}},
+		},
+	}
+	expected := []v1.EnvFromSource{
+		{
+			Prefix: "APP_",
+			ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "app-config"},
+				Optional:             ptrToBool(false),
+			},
+		},
+		{
+			SecretRef: &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "app-secrets"},
+				Optional:             ptrToBool(true),
+			},
+		},
+	}
+
+	envFrom, err := expandContainerEnvFrom(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(envFrom, expected) {
+		t.Fatalf("Unexpected env from sources.\nExpected: %#v\nGiven:    %#v", expected, envFrom)
+	}
+	out := flattenContainerEnvFroms(envFrom)
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Unexpected flattened env from sources.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
+
+func TestFlattenContainerEnvFroms_withoutOptional(t *testing.T) {
+	// Objects created outside of Terraform usually leave optional unset
+	in := []v1.EnvFromSource{
+		{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}},
+		{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-secrets"}}},
+	}
+	expected := []interface{}{
+		map[string]interface{}{"config_map_ref": []interface{}{map[string]interface{}{"name": "app-config"}}},
+		map[string]interface{}{"secret_ref": []interface{}{map[string]interface{}{"name": "app-secrets"}}},
+	}
+
+	out := flattenContainerEnvFroms(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected flattened env from sources.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestExpandFlattenFieldRef_subscript(t *testing.T) {
+	for _, path := range []string{"metadata.name", "metadata.labels['app']", "metadata.annotations['example.com/team']"} {
+		in := []interface{}{map[string]interface{}{
+			"api_version": "v1",
+			"field_path":  path,
+		}}
+		ref, err := expandFieldRef(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref.FieldPath != path {
+			t.Fatalf("Unexpected field path.\nExpected: %q\nGiven:    %q", path, ref.FieldPath)
+		}
+		out := flattenObjectFieldSelector(ref)
+		if !reflect.DeepEqual(out, in) {
+			t.Fatalf("Unexpected flattened field ref.\nExpected: %#v\nGiven:    %#v", in, out)
+		}
+	}
+}
+
+func TestExpandFlattenResourceFieldRef_divisor(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"container_name": "app",
+		"resource":       "limits.memory",
+		"divisor":        "1Mi",
+	}}
+	expected := &v1.ResourceFieldSelector{
+		ContainerName: "app",
+		Resource:      "limits.memory",
+		Divisor:       resource.MustParse("1Mi"),
+	}
+
+	ref, err := expandResourceFieldRef(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ref, expected) {
+		t.Fatalf("Unexpected resource field ref.\nExpected: %#v\nGiven:    %#v", expected, ref)
+	}
+	out := flattenResourceFieldSelector(ref)
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Unexpected flattened resource field ref.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+
+	_, err = expandResourceFieldRef([]interface{}{map[string]interface{}{
+		"resource": "limits.memory",
+		"divisor":  "1 MiB",
+	}})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid divisor")
+	}
+}
@@ -1,11 +1,7 @@
 package kubernetes
 
 import (
-	"strconv"
-
 	"github.com/hashicorp/terraform/helper/schema"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
@@ -14,7 +10,9 @@ func flattenDaemonSetSpec(in v1beta1.DaemonSetSpec, d *schema.ResourceData) ([]i
 	att := make(map[string]interface{})
 	att["min_ready_seconds"] = in.MinReadySeconds
 
-	att["selector"] = in.Selector.MatchLabels
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
 	att["strategy"] = flattenDaemonSetStrategy(in.UpdateStrategy)
 	// podSpec, err := flattenPodSpec(in.Template.Spec)
 	// if err != nil {
@@ -61,10 +59,8 @@ func expandDaemonSetSpec(deployment []interface{}) (v1beta1.DaemonSetSpec, error
 	}
 	in := deployment[0].(map[string]interface{})
 	obj.MinReadySeconds = int32(in["min_ready_seconds"].(int))
-	if v, ok := in["selector"]; ok {
-		obj.Selector = &metav1.LabelSelector{
-			MatchLabels: expandStringMap(v.(map[string]interface{})),
-		}
+	if v, ok := in["selector"].([]interface{}); ok && len(v) > 0 {
+		obj.Selector = expandLabelSelector(v)
 	}
 	obj.UpdateStrategy = expandDaemonSetStrategy(in["strategy"].([]interface{}))
 
@@ -123,21 +119,7 @@ func expandRollingUpdateDaemonSet(p []interface{}) *v1beta1.RollingUpdateDaemonS
 	in := p[0].(map[string]interface{})
 
 	if v, ok := in["max_unavailable"]; ok {
-		obj.MaxUnavailable = expandRollingUpdateDaemonSetIntOrString(v.(string))
+		obj.MaxUnavailable = expandIntOrPercent(v.(string))
 	}
 	return &obj
 }
-
-func expandRollingUpdateDaemonSetIntOrString(v string) *intstr.IntOrString {
-	i, err := strconv.Atoi(v)
-	if err != nil {
-		return &intstr.IntOrString{
-			Type:   intstr.String,
-			StrVal: v,
-		}
-	}
-	return &intstr.IntOrString{
-		Type:   intstr.Int,
-		IntVal: int32(i),
-	}
-}
@@ -1,11 +1,7 @@
 package kubernetes
 
 import (
-	"strconv"
-
 	"github.com/hashicorp/terraform/helper/schema"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
@@ -33,10 +29,24 @@ func flattenDeploymentSpec(in v1beta1.DeploymentSpec, d *schema.ResourceData) ([
 		att["revision_history_limit"] = 10
 	}
 
-	att["selector"] = in.Selector.MatchLabels
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
 	att["strategy"] = flattenDeploymentStrategy(in.Strategy)
+	// The controller clears rollbackTo once done, the configured one is kept
+	// so that it only triggers a rollback when changed
+	if v, ok := d.GetOk("spec.0.rollback_to"); ok {
+		att["rollback_to"] = v
+	}
+
+	// Only affects the provider, so it is kept from the configuration
+	att["wait_for_rollout"] = true
+	if v, ok := d.GetOkExists("spec.0.wait_for_rollout"); ok {
+		att["wait_for_rollout"] = v
+	}
 
 	templateMetadata := flattenMetadata(in.Template.ObjectMeta, d)
+	templateMetadata[0]["annotations"] = removeAnnotations(templateMetadata[0]["annotations"].(map[string]string), deploymentTemplateChecksumAnnotations(d)...)
 	podSpec, err := flattenPodSpec(in.Template.Spec)
 	if err != nil {
 		return nil, err
@@ -72,6 +82,28 @@ func flattenDeploymentStrategyRollingUpdate(in *v1beta1.RollingUpdateDeployment)
 	return []interface{}{att}
 }
 
+func flattenDeploymentStatus(in v1beta1.DeploymentStatus) []interface{} {
+	att := make(map[string]interface{})
+	att["available_replicas"] = int(in.AvailableReplicas)
+	att["ready_replicas"] = int(in.ReadyReplicas)
+	att["updated_replicas"] = int(in.UpdatedReplicas)
+	att["unavailable_replicas"] = int(in.UnavailableReplicas)
+	att["observed_generation"] = int(in.ObservedGeneration)
+
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = map[string]interface{}{
+			"type":    string(c.Type),
+			"status":  string(c.Status),
+			"reason":  c.Reason,
+			"message": c.Message,
+		}
+	}
+	att["condition"] = conditions
+
+	return []interface{}{att}
+}
+
 func expandDeploymentSpec(deployment []interface{}) (v1beta1.DeploymentSpec, error) {
 	obj := v1beta1.DeploymentSpec{}
 	if len(deployment) == 0 || deployment[0] == nil {
@@ -90,9 +122,6 @@ func expandDeploymentSpec(deployment []interface{}) (v1beta1.DeploymentSpec, err
 		obj.RevisionHistoryLimit = ptrToInt32(int32(in["revision_history_limit"].(int)))
 	}
 
-	obj.Selector = &metav1.LabelSelector{
-		MatchLabels: expandStringMap(in["selector"].(map[string]interface{})),
-	}
 	obj.Strategy = expandDeploymentStrategy(in["strategy"].([]interface{}))
 
 	for _, v := range in["template"].([]interface{}) {
@@ -111,9 +140,84 @@ func expandDeploymentSpec(deployment []interface{}) (v1beta1.DeploymentSpec, err
 		}
 	}
 
+	obj.Selector = expandLabelSelector(in["selector"].([]interface{}))
+	if len(obj.Selector.MatchLabels) == 0 && len(obj.Selector.MatchExpressions) == 0 {
+		obj.Selector.MatchLabels = deploymentSelectorFromTemplateLabels(obj.Template.ObjectMeta.Labels)
+	}
+
 	return obj, nil
 }
 
+// patchDeploymentSpec returns the operations updating the fields of the
+// deployment spec which changed, taking their values from the expanded spec
+// since the template carries the annotations injected by the provider. Scaling
+// therefore leaves the template, and what the controller manages, alone.
+// The selector forces a new deployment, so it is never patched.
+// "add" replaces existing members and, unlike "replace", works for the fields
+// omitted while they're empty, e.g. `paused` or `minReadySeconds`.
+func patchDeploymentSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, spec v1beta1.DeploymentSpec) PatchOperations {
+	ops := make([]PatchOperation, 0)
+
+	if d.HasChange(keyPrefix + "min_ready_seconds") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "minReadySeconds",
+			Value: spec.MinReadySeconds,
+		})
+	}
+	if d.HasChange(keyPrefix + "paused") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "paused",
+			Value: spec.Paused,
+		})
+	}
+	if d.HasChange(keyPrefix + "progress_deadline_seconds") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "progressDeadlineSeconds",
+			Value: spec.ProgressDeadlineSeconds,
+		})
+	}
+	if d.HasChange(keyPrefix + "replicas") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "replicas",
+			Value: spec.Replicas,
+		})
+	}
+	if d.HasChange(keyPrefix + "revision_history_limit") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "revisionHistoryLimit",
+			Value: spec.RevisionHistoryLimit,
+		})
+	}
+	if d.HasChange(keyPrefix + "strategy") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "strategy",
+			Value: spec.Strategy,
+		})
+	}
+	if d.HasChange(keyPrefix+"template") || d.HasChange("template_checksums") || d.HasChange("restarted_at") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "template",
+			Value: spec.Template,
+		})
+	}
+
+	return ops
+}
+
+// deploymentSelectorFromTemplateLabels derives the selector used when none is
+// configured. The `app` label is preferred so that adding or changing other
+// template labels doesn't orphan the existing pods.
+func deploymentSelectorFromTemplateLabels(labels map[string]string) map[string]string {
+	if app, ok := labels["app"]; ok {
+		return map[string]string{"app": app}
+	}
+	selector := make(map[string]string, len(labels))
+	for k, v := range labels {
+		selector[k] = v
+	}
+	return selector
+}
+
 func expandDeploymentStrategy(p []interface{}) v1beta1.DeploymentStrategy {
 	obj := v1beta1.DeploymentStrategy{}
 	if len(p) == 0 || p[0] == nil {
@@ -138,24 +242,56 @@ func expandRollingUpdateDeployment(p []interface{}) *v1beta1.RollingUpdateDeploy
 	in := p[0].(map[string]interface{})
 
 	if v, ok := in["max_surge"]; ok {
-		obj.MaxSurge = expandRollingUpdateDeploymentIntOrString(v.(string))
+		obj.MaxSurge = expandIntOrPercent(v.(string))
 	}
 	if v, ok := in["max_unavailable"]; ok {
-		obj.MaxUnavailable = expandRollingUpdateDeploymentIntOrString(v.(string))
+		obj.MaxUnavailable = expandIntOrPercent(v.(string))
 	}
 	return &obj
 }
 
-func expandRollingUpdateDeploymentIntOrString(v string) *intstr.IntOrString {
-	i, err := strconv.Atoi(v)
-	if err != nil {
-		return &intstr.IntOrString{
-			Type:   intstr.String,
-			StrVal: v,
+func expandDeploymentWaitConditions(in []interface{}) []v1beta1.DeploymentCondition {
+	conditions := make([]v1beta1.DeploymentCondition, 0, len(in))
+	for _, c := range in {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
 		}
+		conditions = append(conditions, v1beta1.DeploymentCondition{
+			Type:   v1beta1.DeploymentConditionType(m["condition"].(string)),
+			Status: v1.ConditionStatus(m["status"].(string)),
+		})
 	}
-	return &intstr.IntOrString{
-		Type:   intstr.Int,
-		IntVal: int32(i),
+	return conditions
+}
+
+func containerImagesByName(containers []v1.Container) map[string]string {
+	images := make(map[string]string, len(containers))
+	for _, c := range containers {
+		images[c.Name] = c.Image
+	}
+	return images
+}
+
+func containerImagesFromConfig(containers []interface{}) map[string]string {
+	images := make(map[string]string, len(containers))
+	for _, v := range containers {
+		c := v.(map[string]interface{})
+		images[c["name"].(string)] = c["image"].(string)
+	}
+	return images
+}
+
+// overrideContainerImages replaces the image of the named containers, for the
+// containers whose image is owned by another tool. Containers missing from
+// images are left as they are.
+func overrideContainerImages(containers []v1.Container, images map[string]string, names *schema.Set) {
+	for i, c := range containers {
+		if !names.Contains(c.Name) {
+			continue
+		}
+		if image, ok := images[c.Name]; ok {
+			containers[i].Image = image
+		}
 	}
 }
@@ -0,0 +1,414 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestDeploymentSelectorFromTemplateLabels(t *testing.T) {
+	testCases := []struct {
+		Labels   map[string]string
+		Expected map[string]string
+	}{
+		{
+			map[string]string{"app": "web", "tier": "frontend"},
+			map[string]string{"app": "web"},
+		},
+		{
+			map[string]string{"tier": "frontend", "track": "stable"},
+			map[string]string{"tier": "frontend", "track": "stable"},
+		},
+		{
+			map[string]string{},
+			map[string]string{},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			selector := deploymentSelectorFromTemplateLabels(tc.Labels)
+			if !reflect.DeepEqual(selector, tc.Expected) {
+				t.Fatalf("Unexpected selector.\nExpected: %#v\nGiven:    %#v", tc.Expected, selector)
+			}
+		})
+	}
+}
+
+func TestGracePeriodAwareTimeout(t *testing.T) {
+	testCases := []struct {
+		Configured  time.Duration
+		GracePeriod int
+		Expected    time.Duration
+	}{
+		{10 * time.Minute, 30, 10 * time.Minute},
+		{30 * time.Second, 30, time.Minute},
+		{time.Minute, 0, time.Minute},
+		{5 * time.Minute, 600, 630 * time.Second},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			timeout := gracePeriodAwareTimeout(tc.Configured, tc.GracePeriod)
+			if timeout != tc.Expected {
+				t.Fatalf("Expected timeout of %s, given %s", tc.Expected, timeout)
+			}
+		})
+	}
+}
+
+func TestDrainTimeoutError(t *testing.T) {
+	err := drainTimeoutError("web", time.Minute, time.Minute, fmt.Errorf("waiting for 1 replica"))
+	if strings.Contains(err.Error(), "extended") {
+		t.Fatalf("Unexpected mention of an extended timeout: %s", err)
+	}
+
+	err = drainTimeoutError("web", 630*time.Second, 5*time.Minute, fmt.Errorf("waiting for 1 replica"))
+	expected := "within 10m30s (the delete timeout of 5m0s, extended to the pods' termination grace period plus 30s)"
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected the error to contain %q, given: %s", expected, err)
+	}
+}
+
+func TestOverrideContainerImages(t *testing.T) {
+	containers := []v1.Container{
+		{Name: "app", Image: "example/app:1.0"},
+		{Name: "sidecar", Image: "example/sidecar:1.0"},
+		{Name: "proxy", Image: "example/proxy:1.0"},
+	}
+	images := map[string]string{
+		"app":     "example/app:2.0",
+		"sidecar": "example/sidecar:2.0",
+	}
+	names := schema.NewSet(schema.HashString, []interface{}{"app", "proxy"})
+
+	overrideContainerImages(containers, images, names)
+
+	expected := []v1.Container{
+		{Name: "app", Image: "example/app:2.0"},
+		{Name: "sidecar", Image: "example/sidecar:1.0"},
+		{Name: "proxy", Image: "example/proxy:1.0"},
+	}
+	if !reflect.DeepEqual(containers, expected) {
+		t.Fatalf("Unexpected containers.\nExpected: %#v\nGiven:    %#v", expected, containers)
+	}
+}
+
+func TestFlattenDeploymentStatus(t *testing.T) {
+	in := v1beta1.DeploymentStatus{
+		ObservedGeneration:  3,
+		Replicas:            3,
+		UpdatedReplicas:     2,
+		ReadyReplicas:       2,
+		AvailableReplicas:   2,
+		UnavailableReplicas: 1,
+		Conditions: []v1beta1.DeploymentCondition{
+			{
+				Type:    v1beta1.DeploymentProgressing,
+				Status:  v1.ConditionTrue,
+				Reason:  "ReplicaSetUpdated",
+				Message: `ReplicaSet "web-5d8f7" is progressing.`,
+			},
+		},
+	}
+	expected := []interface{}{map[string]interface{}{
+		"available_replicas":   2,
+		"ready_replicas":       2,
+		"updated_replicas":     2,
+		"unavailable_replicas": 1,
+		"observed_generation":  3,
+		"condition": []interface{}{
+			map[string]interface{}{
+				"type":    "Progressing",
+				"status":  "True",
+				"reason":  "ReplicaSetUpdated",
+				"message": `ReplicaSet "web-5d8f7" is progressing.`,
+			},
+		},
+	}}
+
+	out := flattenDeploymentStatus(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected status.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestExpandFlattenDeploymentSpec_revisionHistoryLimitZero(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesDeployment().Schema, map[string]interface{}{
+		"spec": []interface{}{map[string]interface{}{
+			"revision_history_limit": 0,
+			"template": []interface{}{map[string]interface{}{
+				"spec": []interface{}{map[string]interface{}{
+					"container": []interface{}{map[string]interface{}{
+						"name":  "web",
+						"image": "nginx:1.7.8",
+					}},
+				}},
+			}},
+		}},
+	})
+
+	spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.RevisionHistoryLimit == nil || *spec.RevisionHistoryLimit != 0 {
+		t.Fatalf("Unexpected revision history limit.\nExpected: 0\nGiven:    %#v", spec.RevisionHistoryLimit)
+	}
+
+	flattened, err := flattenDeploymentSpec(spec, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit := flattened[0].(map[string]interface{})["revision_history_limit"]; limit != int32(0) {
+		t.Fatalf("Unexpected flattened revision history limit.\nExpected: %#v\nGiven:    %#v", int32(0), limit)
+	}
+}
+
+func TestExpandRollingUpdateDeployment(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"max_surge":       "25%",
+		"max_unavailable": "0",
+	}}
+	expected := &v1beta1.RollingUpdateDeployment{
+		MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+		MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+	}
+	out := expandRollingUpdateDeployment(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected rolling update.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestExpandDeploymentWaitConditions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesDeployment().Schema, map[string]interface{}{
+		"wait_for": []interface{}{
+			map[string]interface{}{"condition": "Available"},
+			map[string]interface{}{"condition": "ReplicaFailure", "status": "False"},
+		},
+	})
+	expected := []v1beta1.DeploymentCondition{
+		{Type: v1beta1.DeploymentAvailable, Status: v1.ConditionTrue},
+		{Type: v1beta1.DeploymentReplicaFailure, Status: v1.ConditionFalse},
+	}
+	out := expandDeploymentWaitConditions(d.Get("wait_for").([]interface{}))
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected wait conditions.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestPatchDeploymentSpec(t *testing.T) {
+	spec := func(replicas int, image string) []map[string]interface{} {
+		return []map[string]interface{}{{
+			"replicas": replicas,
+			"template": []map[string]interface{}{{
+				"spec": []map[string]interface{}{{
+					"container": []map[string]interface{}{{
+						"name":  "web",
+						"image": image,
+					}},
+				}},
+			}},
+		}}
+	}
+	cases := []struct {
+		Name     string
+		Replicas int
+		Image    string
+		Paths    []string
+	}{
+		{"unchanged", 1, "nginx:1.7.8", []string{}},
+		{"scaled", 3, "nginx:1.7.8", []string{"/spec/replicas"}},
+		{"new image", 1, "nginx:1.7.9", []string{"/spec/template"}},
+		{"scaled with new image", 3, "nginx:1.7.9", []string{"/spec/replicas", "/spec/template"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			paths := []string{}
+			// Only the update of a resource sees the changes of its attributes
+			r := &schema.Resource{
+				Schema: resourceKubernetesDeployment().Schema,
+				Update: func(d *schema.ResourceData, meta interface{}) error {
+					expanded, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
+					if err != nil {
+						return err
+					}
+					for _, op := range patchDeploymentSpec("spec.0.", "/spec/", d, expanded) {
+						paths = append(paths, op.GetPath())
+					}
+					return nil
+				},
+			}
+
+			old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec":     spec(1, "nginx:1.7.8"),
+			})
+			old.SetId("default/web")
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec":     spec(tc.Replicas, tc.Image),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff, err := r.Diff(old.State(), terraform.NewResourceConfig(raw), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil {
+				diff = &terraform.InstanceDiff{}
+			}
+			if _, err := r.Apply(old.State(), diff, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(paths, tc.Paths) {
+				t.Fatalf("Unexpected patched paths.\nExpected: %#v\nGiven:    %#v", tc.Paths, paths)
+			}
+		})
+	}
+}
+
+func TestDeploymentSelectorCustomizeDiff(t *testing.T) {
+	// The service account isn't found, which only defers the image pull secrets
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	k, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := func(labels map[string]interface{}) []map[string]interface{} {
+		return []map[string]interface{}{{
+			"selector": []map[string]interface{}{{
+				"match_labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+			}},
+			"template": []map[string]interface{}{{
+				"metadata": []map[string]interface{}{{"labels": labels}},
+				"spec": []map[string]interface{}{{
+					"container": []map[string]interface{}{{
+						"name":  "web",
+						"image": "nginx:1.7.8",
+					}},
+				}},
+			}},
+		}}
+	}
+	cases := []struct {
+		Name        string
+		Labels      map[string]interface{}
+		RequiresNew bool
+	}{
+		{"label added", map[string]interface{}{"app": "web", "tier": "frontend", "track": "stable"}, false},
+		{"selected label changed", map[string]interface{}{"app": "web", "tier": "backend"}, true},
+		{"selected label removed", map[string]interface{}{"app": "web"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := resourceKubernetesDeployment()
+			old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec":     spec(map[string]interface{}{"app": "web", "tier": "frontend"}),
+			})
+			old.SetId("default/web")
+			// Read always sets the status of the deployment
+			if err := old.Set("status", flattenDeploymentStatus(v1beta1.DeploymentStatus{})); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec":     spec(tc.Labels),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff, err := r.Diff(old.State(), terraform.NewResourceConfig(raw), &kubeProvider{Clientset: k})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.RequiresNew() != tc.RequiresNew {
+				t.Fatalf("Unexpected replacement of the deployment.\nExpected: %t\nGiven:    %t", tc.RequiresNew, diff.RequiresNew())
+			}
+		})
+	}
+}
+
+func TestDeploymentImagePullSecretsCustomizeDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/serviceaccounts/builder":
+			w.Write([]byte(`{"metadata":{"name":"builder"},"imagePullSecrets":[{"name":"registry"}]}`))
+		case "/api/v1/namespaces/default/serviceaccounts/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		}
+	}))
+	defer server.Close()
+
+	k, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		ServiceAccount string
+		Expected       *terraform.ResourceAttrDiff
+	}{
+		{"builder", &terraform.ResourceAttrDiff{Old: "0", New: "1"}},
+		{"missing", &terraform.ResourceAttrDiff{Old: "0", NewComputed: true}},
+		{"forbidden", &terraform.ResourceAttrDiff{Old: "0", NewComputed: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.ServiceAccount, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"template": []map[string]interface{}{{
+						"metadata": []map[string]interface{}{{"labels": map[string]interface{}{"app": "web"}}},
+						"spec": []map[string]interface{}{{
+							"service_account_name": tc.ServiceAccount,
+							"container": []map[string]interface{}{{
+								"name":  "web",
+								"image": "nginx:1.7.8",
+							}},
+						}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff, err := resourceKubernetesDeployment().Diff(nil, terraform.NewResourceConfig(raw), &kubeProvider{Clientset: k})
+			if err != nil {
+				t.Fatal(err)
+			}
+			attr := diff.Attributes["effective_image_pull_secrets.#"]
+			if !reflect.DeepEqual(attr, tc.Expected) {
+				t.Fatalf("Unexpected diff of the image pull secrets.\nExpected: %#v\nGiven:    %#v", tc.Expected, attr)
+			}
+			if !tc.Expected.NewComputed && diff.Attributes["effective_image_pull_secrets.0"].New != "registry" {
+				t.Fatalf("Unexpected image pull secret.\nExpected: %q\nGiven:    %#v", "registry", diff.Attributes["effective_image_pull_secrets.0"])
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func flattenEndpointsAddresses(in []v1.EndpointAddress) *schema.Set {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		m := make(map[string]interface{})
+		m["ip"] = n.IP
+		if n.Hostname != "" {
+			m["hostname"] = n.Hostname
+		}
+		if n.NodeName != nil {
+			m["node_name"] = *n.NodeName
+		}
+		att[i] = m
+	}
+	return schema.NewSet(schema.HashResource(endpointAddressResource()), att)
+}
+
+func flattenEndpointsPorts(in []v1.EndpointPort) *schema.Set {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		m := make(map[string]interface{})
+		m["port"] = int(n.Port)
+		if n.Name != "" {
+			m["name"] = n.Name
+		}
+		if n.Protocol != "" {
+			m["protocol"] = string(n.Protocol)
+		}
+		att[i] = m
+	}
+	return schema.NewSet(schema.HashResource(endpointPortResource()), att)
+}
+
+func flattenEndpointsSubsets(in []v1.EndpointSubset) *schema.Set {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		m := make(map[string]interface{})
+		m["address"] = flattenEndpointsAddresses(n.Addresses)
+		m["not_ready_address"] = flattenEndpointsAddresses(n.NotReadyAddresses)
+		m["port"] = flattenEndpointsPorts(n.Ports)
+		att[i] = m
+	}
+	return schema.NewSet(schema.HashResource(endpointSubsetResource()), att)
+}
+
+func expandEndpointsAddresses(in *schema.Set) []v1.EndpointAddress {
+	if in == nil || in.Len() == 0 {
+		return nil
+	}
+	obj := make([]v1.EndpointAddress, in.Len(), in.Len())
+	for i, n := range in.List() {
+		cfg := n.(map[string]interface{})
+		obj[i] = v1.EndpointAddress{
+			IP: cfg["ip"].(string),
+		}
+		if v, ok := cfg["hostname"].(string); ok {
+			obj[i].Hostname = v
+		}
+		if v, ok := cfg["node_name"].(string); ok && v != "" {
+			obj[i].NodeName = &v
+		}
+	}
+	return obj
+}
+
+func expandEndpointsPorts(in *schema.Set) []v1.EndpointPort {
+	if in == nil || in.Len() == 0 {
+		return nil
+	}
+	obj := make([]v1.EndpointPort, in.Len(), in.Len())
+	for i, n := range in.List() {
+		cfg := n.(map[string]interface{})
+		obj[i] = v1.EndpointPort{
+			Port: int32(cfg["port"].(int)),
+		}
+		if v, ok := cfg["name"].(string); ok {
+			obj[i].Name = v
+		}
+		if v, ok := cfg["protocol"].(string); ok {
+			obj[i].Protocol = v1.Protocol(v)
+		}
+	}
+	return obj
+}
+
+func expandEndpointsSubsets(in *schema.Set) []v1.EndpointSubset {
+	obj := make([]v1.EndpointSubset, in.Len(), in.Len())
+	for i, n := range in.List() {
+		cfg := n.(map[string]interface{})
+		obj[i] = v1.EndpointSubset{
+			Addresses:         expandEndpointsAddresses(cfg["address"].(*schema.Set)),
+			NotReadyAddresses: expandEndpointsAddresses(cfg["not_ready_address"].(*schema.Set)),
+			Ports:             expandEndpointsPorts(cfg["port"].(*schema.Set)),
+		}
+	}
+	return obj
+}
@@ -0,0 +1,30 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestEndpointsSubsetsRoundTrip(t *testing.T) {
+	node := "node-1"
+	in := []v1.EndpointSubset{
+		{
+			Addresses: []v1.EndpointAddress{
+				{IP: "10.0.0.1", Hostname: "backend-1", NodeName: &node},
+			},
+			NotReadyAddresses: []v1.EndpointAddress{
+				{IP: "10.0.0.2"},
+			},
+			Ports: []v1.EndpointPort{
+				{Name: "http", Port: 80, Protocol: v1.ProtocolTCP},
+			},
+		},
+	}
+
+	out := expandEndpointsSubsets(flattenEndpointsSubsets(in))
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Endpoints subsets didn't round-trip.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -14,6 +15,9 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 	if in.ActiveDeadlineSeconds != nil {
 		att["active_deadline_seconds"] = *in.ActiveDeadlineSeconds
 	}
+	if in.Affinity != nil {
+		att["affinity"] = flattenAffinity(in.Affinity)
+	}
 	containers, err := flattenContainers(in.Containers)
 	if err != nil {
 		return nil, err
@@ -66,6 +70,10 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 		att["termination_grace_period_seconds"] = *in.TerminationGracePeriodSeconds
 	}
 
+	if tolerations := flattenTolerations(in.Tolerations); len(tolerations) > 0 {
+		att["toleration"] = tolerations
+	}
+
 	if len(in.Volumes) > 0 {
 		v, err := flattenVolumes(in.Volumes)
 		if err != nil {
@@ -122,6 +130,56 @@ func flattenSeLinuxOptions(in *v1.SELinuxOptions) []interface{} {
 	return []interface{}{att}
 }
 
+// defaultTolerationKeys are the taints the DefaultTolerationSeconds admission
+// controller adds NoExecute tolerations for to every pod that has none.
+var defaultTolerationKeys = []string{
+	"node.alpha.kubernetes.io/notReady",
+	"node.alpha.kubernetes.io/unreachable",
+	"node.kubernetes.io/not-ready",
+	"node.kubernetes.io/unreachable",
+}
+
+func isDefaultToleration(t v1.Toleration) bool {
+	if t.Operator != v1.TolerationOpExists || t.Effect != v1.TaintEffectNoExecute ||
+		t.TolerationSeconds == nil || *t.TolerationSeconds != 300 {
+		return false
+	}
+	for _, k := range defaultTolerationKeys {
+		if t.Key == k {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenTolerations(tolerations []v1.Toleration) []interface{} {
+	att := []interface{}{}
+	for _, t := range tolerations {
+		// The injected tolerations would otherwise show up as a diff against every config
+		if isDefaultToleration(t) {
+			continue
+		}
+		m := map[string]interface{}{}
+		if t.Effect != "" {
+			m["effect"] = string(t.Effect)
+		}
+		if t.Key != "" {
+			m["key"] = t.Key
+		}
+		if t.Operator != "" {
+			m["operator"] = string(t.Operator)
+		}
+		if t.TolerationSeconds != nil {
+			m["toleration_seconds"] = strconv.FormatInt(*t.TolerationSeconds, 10)
+		}
+		if t.Value != "" {
+			m["value"] = t.Value
+		}
+		att = append(att, m)
+	}
+	return att
+}
+
 func flattenVolumes(volumes []v1.Volume) ([]interface{}, error) {
 	att := make([]interface{}, len(volumes))
 	for i, v := range volumes {
@@ -272,12 +330,17 @@ func flattenConfigMapVolumeSource(in *v1.ConfigMapVolumeSource) []interface{} {
 		for i, v := range in.Items {
 			m := map[string]interface{}{}
 			m["key"] = v.Key
-			m["mode"] = int(*v.Mode)
+			if v.Mode != nil {
+				m["mode"] = int(*v.Mode)
+			}
 			m["path"] = v.Path
 			items[i] = m
 		}
 		att["items"] = items
 	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
 
 	return []interface{}{att}
 }
@@ -328,6 +391,10 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.ActiveDeadlineSeconds = ptrToInt64(int64(v))
 	}
 
+	if v, ok := in["affinity"].([]interface{}); ok && len(v) > 0 {
+		obj.Affinity = expandAffinity(v)
+	}
+
 	if v, ok := in["container"].([]interface{}); ok && len(v) > 0 {
 		cs, err := expandContainers(v)
 		if err != nil {
@@ -407,6 +474,14 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.TerminationGracePeriodSeconds = ptrToInt64(int64(v))
 	}
 
+	if v, ok := in["toleration"].([]interface{}); ok && len(v) > 0 {
+		ts, err := expandTolerations(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Tolerations = ts
+	}
+
 	if v, ok := in["volume"].([]interface{}); ok && len(v) > 0 {
 		cs, err := expandVolumes(v)
 		if err != nil {
@@ -474,7 +549,7 @@ func expandKeyPath(in []interface{}) []v1.KeyToPath {
 		if v, ok := p["key"].(string); ok {
 			keyPaths[i].Key = v
 		}
-		if v, ok := p["mode"].(int); ok {
+		if v, ok := p["mode"].(int); ok && v != 0 {
 			keyPaths[i].Mode = ptrToInt32(int32(v))
 		}
 		if v, ok := p["path"].(string); ok {
@@ -520,13 +595,19 @@ func expandConfigMapVolumeSource(l []interface{}) *v1.ConfigMapVolumeSource {
 		return &v1.ConfigMapVolumeSource{}
 	}
 	in := l[0].(map[string]interface{})
-	obj := &v1.ConfigMapVolumeSource{
-		DefaultMode: ptrToInt32(int32(in["default_mode"].(int))),
-	}
+	obj := &v1.ConfigMapVolumeSource{}
 
+	// Leave the default mode unset so the API server defaults it to 0644
+	// instead of creating unreadable files.
+	if v, ok := in["default_mode"].(int); ok && v != 0 {
+		obj.DefaultMode = ptrToInt32(int32(v))
+	}
 	if v, ok := in["name"].(string); ok {
 		obj.Name = v
 	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = ptrToBool(v)
+	}
 
 	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
 		obj.Items = expandKeyPath(v)
@@ -540,8 +621,11 @@ func expandDownwardAPIVolumeSource(l []interface{}) (*v1.DownwardAPIVolumeSource
 		return &v1.DownwardAPIVolumeSource{}, nil
 	}
 	in := l[0].(map[string]interface{})
-	obj := &v1.DownwardAPIVolumeSource{
-		DefaultMode: ptrToInt32(int32(in["default_mode"].(int))),
+	obj := &v1.DownwardAPIVolumeSource{}
+	// Leave the default mode unset so the API server defaults it to 0644
+	// instead of creating unreadable files.
+	if v, ok := in["default_mode"].(int); ok && v != 0 {
+		obj.DefaultMode = ptrToInt32(int32(v))
 	}
 	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
 		var err error
@@ -614,6 +698,36 @@ func expandSecretVolumeSource(l []interface{}) *v1.SecretVolumeSource {
 	return obj
 }
 
+func expandTolerations(tolerations []interface{}) ([]v1.Toleration, error) {
+	ts := make([]v1.Toleration, len(tolerations))
+	for i, t := range tolerations {
+		if t == nil {
+			continue
+		}
+		m := t.(map[string]interface{})
+		if v, ok := m["effect"].(string); ok {
+			ts[i].Effect = v1.TaintEffect(v)
+		}
+		if v, ok := m["key"].(string); ok {
+			ts[i].Key = v
+		}
+		if v, ok := m["operator"].(string); ok {
+			ts[i].Operator = v1.TolerationOperator(v)
+		}
+		if v, ok := m["toleration_seconds"].(string); ok && v != "" {
+			seconds, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return ts, fmt.Errorf("toleration.%d: invalid toleration_seconds %q: %s", i, v, err)
+			}
+			ts[i].TolerationSeconds = &seconds
+		}
+		if v, ok := m["value"].(string); ok {
+			ts[i].Value = v
+		}
+	}
+	return ts, nil
+}
+
 func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
 	if len(volumes) == 0 {
 		return []v1.Volume{}, nil
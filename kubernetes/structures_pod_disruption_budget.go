@@ -0,0 +1,47 @@
+package kubernetes
+
+import (
+	api "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+func expandPodDisruptionBudgetSpec(in []interface{}) api.PodDisruptionBudgetSpec {
+	spec := api.PodDisruptionBudgetSpec{}
+	if len(in) == 0 || in[0] == nil {
+		return spec
+	}
+	m := in[0].(map[string]interface{})
+
+	if v, ok := m["min_available"].(string); ok && v != "" {
+		spec.MinAvailable = expandIntOrPercent(v)
+	}
+	if v, ok := m["max_unavailable"].(string); ok && v != "" {
+		spec.MaxUnavailable = expandIntOrPercent(v)
+	}
+	if v, ok := m["selector"].([]interface{}); ok && len(v) > 0 {
+		spec.Selector = expandLabelSelector(v)
+	}
+	return spec
+}
+
+func flattenPodDisruptionBudgetSpec(in api.PodDisruptionBudgetSpec) []interface{} {
+	att := make(map[string]interface{})
+	if in.MinAvailable != nil {
+		att["min_available"] = in.MinAvailable.String()
+	}
+	if in.MaxUnavailable != nil {
+		att["max_unavailable"] = in.MaxUnavailable.String()
+	}
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
+	return []interface{}{att}
+}
+
+func flattenPodDisruptionBudgetStatus(in api.PodDisruptionBudgetStatus) []interface{} {
+	att := make(map[string]interface{})
+	att["current_healthy"] = int(in.CurrentHealthy)
+	att["desired_healthy"] = int(in.DesiredHealthy)
+	att["disruptions_allowed"] = int(in.PodDisruptionsAllowed)
+	att["expected_pods"] = int(in.ExpectedPods)
+	return []interface{}{att}
+}
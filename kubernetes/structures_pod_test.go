@@ -0,0 +1,211 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestExpandConfigMapVolumeSource(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    []interface{}
+		Expected *v1.ConfigMapVolumeSource
+	}{
+		{
+			"defaults left to the API server",
+			[]interface{}{map[string]interface{}{
+				"name":         "app-config",
+				"default_mode": 0,
+				"optional":     false,
+			}},
+			&v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "app-config"},
+			},
+		},
+		{
+			"optional with items",
+			[]interface{}{map[string]interface{}{
+				"name":         "app-config",
+				"default_mode": 0440,
+				"optional":     true,
+				"items": []interface{}{
+					map[string]interface{}{"key": "log.level", "path": "log/level"},
+					map[string]interface{}{"key": "app.yaml", "path": "app.yaml", "mode": 0400},
+				},
+			}},
+			&v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "app-config"},
+				DefaultMode:          ptrToInt32(0440),
+				Optional:             ptrToBool(true),
+				Items: []v1.KeyToPath{
+					{Key: "log.level", Path: "log/level"},
+					{Key: "app.yaml", Path: "app.yaml", Mode: ptrToInt32(0400)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := expandConfigMapVolumeSource(tc.Input)
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected volume source.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestExpandDownwardAPIVolumeSource_defaultMode(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Mode     int
+		Expected *v1.DownwardAPIVolumeSource
+	}{
+		{
+			"left to the API server",
+			0,
+			&v1.DownwardAPIVolumeSource{},
+		},
+		{
+			"configured",
+			0440,
+			&v1.DownwardAPIVolumeSource{DefaultMode: ptrToInt32(0440)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out, err := expandDownwardAPIVolumeSource([]interface{}{map[string]interface{}{
+				"default_mode": tc.Mode,
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected volume source.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestFlattenConfigMapVolumeSource_itemWithoutMode(t *testing.T) {
+	in := &v1.ConfigMapVolumeSource{
+		LocalObjectReference: v1.LocalObjectReference{Name: "app-config"},
+		DefaultMode:          ptrToInt32(0644),
+		Optional:             ptrToBool(true),
+		Items:                []v1.KeyToPath{{Key: "log.level", Path: "log/level"}},
+	}
+	expected := []interface{}{map[string]interface{}{
+		"name":         "app-config",
+		"default_mode": int32(0644),
+		"optional":     true,
+		"items": []interface{}{
+			map[string]interface{}{"key": "log.level", "path": "log/level"},
+		},
+	}}
+
+	out := flattenConfigMapVolumeSource(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected flattened volume source.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestExpandTolerations(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Input       []interface{}
+		Expected    []v1.Toleration
+		ExpectError bool
+	}{
+		{
+			"equal with value",
+			[]interface{}{map[string]interface{}{
+				"effect":             "NoSchedule",
+				"key":                "nvidia.com/gpu",
+				"operator":           "Equal",
+				"toleration_seconds": "",
+				"value":              "present",
+			}},
+			[]v1.Toleration{
+				{Key: "nvidia.com/gpu", Operator: v1.TolerationOpEqual, Value: "present", Effect: v1.TaintEffectNoSchedule},
+			},
+			false,
+		},
+		{
+			"exists with toleration seconds",
+			[]interface{}{map[string]interface{}{
+				"effect":             "NoExecute",
+				"key":                "node.kubernetes.io/unreachable",
+				"operator":           "Exists",
+				"toleration_seconds": "60",
+				"value":              "",
+			}},
+			[]v1.Toleration{
+				{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(60)},
+			},
+			false,
+		},
+		{
+			"evicted immediately",
+			[]interface{}{map[string]interface{}{
+				"effect":             "NoExecute",
+				"key":                "node.kubernetes.io/unreachable",
+				"operator":           "Exists",
+				"toleration_seconds": "0",
+			}},
+			[]v1.Toleration{
+				{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(0)},
+			},
+			false,
+		},
+		{
+			"invalid toleration seconds",
+			[]interface{}{map[string]interface{}{"key": "dedicated", "operator": "Exists", "toleration_seconds": "soon"}},
+			nil,
+			true,
+		},
+		{
+			"exists without key tolerates everything",
+			[]interface{}{map[string]interface{}{"operator": "Exists"}},
+			[]v1.Toleration{{Operator: v1.TolerationOpExists}},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out, err := expandTolerations(tc.Input)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Unexpected tolerations.\nExpected: %#v\nGiven:    %#v", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestFlattenTolerations_skipsDefaults(t *testing.T) {
+	in := []v1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+		{Key: "node.alpha.kubernetes.io/notReady", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(300)},
+		{Key: "node.alpha.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(60)},
+	}
+	expected := []interface{}{
+		map[string]interface{}{"effect": "NoSchedule", "key": "nvidia.com/gpu", "operator": "Exists"},
+		map[string]interface{}{"effect": "NoExecute", "key": "node.alpha.kubernetes.io/unreachable", "operator": "Exists", "toleration_seconds": "60"},
+	}
+
+	out := flattenTolerations(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected flattened tolerations.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/apps/v1beta1"
 )
@@ -20,7 +19,9 @@ func flattenStatefulSetSpec(in v1beta1.StatefulSetSpec, d *schema.ResourceData)
 		att["revision_history_limit"] = *in.RevisionHistoryLimit
 	}
 	att["service_name"] = in.ServiceName
-	att["selector"] = in.Selector.MatchLabels
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
 	att["update_strategy"] = flattenStatefulSetUpdateStrategy(in.UpdateStrategy, d)
 
 	templateMetadata := flattenMetadata(in.Template.ObjectMeta, d)
@@ -79,9 +80,7 @@ func expandStatefulSetSpec(statefulSet []interface{}) (v1beta1.StatefulSetSpec,
 		obj.UpdateStrategy = expandStatefulSetUpdateStrategy(v.([]interface{}))
 	}
 	obj.Replicas = ptrToInt32(int32(in["replicas"].(int)))
-	obj.Selector = &metav1.LabelSelector{
-		MatchLabels: expandStringMap(in["selector"].(map[string]interface{})),
-	}
+	obj.Selector = expandLabelSelector(in["selector"].([]interface{}))
 	obj.ServiceName = in["service_name"].(string)
 
 	for _, v := range in["template"].([]interface{}) {
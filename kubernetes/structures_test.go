@@ -2,7 +2,10 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestIsInternalKey(t *testing.T) {
@@ -30,3 +33,20 @@ func TestIsInternalKey(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandIntOrPercent(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected intstr.IntOrString
+	}{
+		{"0", intstr.FromInt(0)},
+		{"3", intstr.FromInt(3)},
+		{"25%", intstr.FromString("25%")},
+	}
+	for _, tc := range testCases {
+		out := expandIntOrPercent(tc.Value)
+		if !reflect.DeepEqual(*out, tc.Expected) {
+			t.Fatalf("Unexpected value for %q.\nExpected: %#v\nGiven:    %#v", tc.Value, tc.Expected, *out)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package kubernetes
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -9,6 +12,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/labels"
 	utilValidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -25,6 +29,10 @@ func validateAnnotations(value interface{}, key string) (ws []string, es []error
 	return
 }
 
+func validateAnnotationName(value interface{}, key string) (ws []string, es []error) {
+	return validateAnnotations(map[string]interface{}{value.(string): ""}, key)
+}
+
 func validateName(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 
@@ -142,10 +150,59 @@ func validatePositiveInteger(value interface{}, key string) (ws []string, es []e
 	return
 }
 
+func validateNonNegativeInteger(value interface{}, key string) (ws []string, es []error) {
+	v := value.(int)
+	if v < 0 {
+		es = append(es, fmt.Errorf("%s must be greater than or equal to 0", key))
+	}
+	return
+}
+
+// validateNonNegativeIntegerString checks integers held in string attributes,
+// where the empty string, unlike "0", stands for a value that isn't set.
+func validateNonNegativeIntegerString(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if v == "" {
+		return
+	}
+	if i, err := strconv.Atoi(v); err != nil || i < 0 {
+		es = append(es, fmt.Errorf("%s must be an integer greater than or equal to 0, got %q", key, v))
+	}
+	return
+}
+
+var intOrPercentRegexp = regexp.MustCompile(`^(0|[1-9][0-9]*)%?$`)
+
+// validateIntOrPercent checks that an int-or-string value like max_surge is
+// either a non-negative integer or a percentage, in the canonical form the API
+// returns it in, so that it doesn't show a diff after being read back.
+func validateIntOrPercent(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if !intOrPercentRegexp.MatchString(v) {
+		es = append(es, fmt.Errorf("%s must be a non-negative integer, e.g. \"1\", or a percentage, e.g. \"25%%\", got %q", key, v))
+	}
+	return
+}
+
+func validateIntegerInRange(min, max int) schema.SchemaValidateFunc {
+	return func(value interface{}, key string) (ws []string, es []error) {
+		v := value.(int)
+		if v < min || v > max {
+			es = append(es, fmt.Errorf("%s must be in the range %d to %d, got %d", key, min, max, v))
+		}
+		return
+	}
+}
+
 func validateDNSPolicy(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
-	if v != "ClusterFirst" && v != "Default" {
-		es = append(es, fmt.Errorf("%s must be either ClusterFirst or Default", key))
+	switch v {
+	case "ClusterFirst", "ClusterFirstWithHostNet", "Default":
+	case "None":
+		// The API rejects None without a dnsConfig, which this client lacks
+		es = append(es, fmt.Errorf("%s None requires a DNS config, which isn't supported yet", key))
+	default:
+		es = append(es, fmt.Errorf("%s must be one of ClusterFirst, ClusterFirstWithHostNet or Default", key))
 	}
 	return
 }
@@ -208,3 +265,235 @@ func validateAttributeValueIsIn(validValues []string) schema.SchemaValidateFunc
 
 	}
 }
+
+func validateLabelSelector(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if _, err := labels.Parse(v); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid label selector: %s", key, v, err))
+	}
+	return
+}
+
+func validateIPAddress(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if net.ParseIP(v) == nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid IP address", key, v))
+	}
+	return
+}
+
+func validateHostname(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	for _, msg := range utilValidation.IsDNS1123Subdomain(v) {
+		es = append(es, fmt.Errorf("%s (%q) %s", key, v, msg))
+	}
+	return
+}
+
+// undefinedEnvVarReferences lists the `$(VAR)` references in the command, args
+// and env values of a container that don't resolve to a variable defined in its
+// env list. Env values may only reference variables defined before them.
+// Variables provided through env_from are unknown at plan time, so containers
+// using it are skipped.
+func undefinedEnvVarReferences(container map[string]interface{}) []string {
+	if envFrom, ok := container["env_from"].([]interface{}); ok && len(envFrom) > 0 {
+		return nil
+	}
+
+	name, _ := container["name"].(string)
+	var errs []string
+	defined := make(map[string]bool)
+	env, _ := container["env"].([]interface{})
+	for i, e := range env {
+		envVar, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, _ := envVar["value"].(string)
+		for _, ref := range envVarReferences(value) {
+			if !defined[ref] {
+				errs = append(errs, fmt.Sprintf("container %q: env.%d.value references $(%s)", name, i, ref))
+			}
+		}
+		if n, ok := envVar["name"].(string); ok {
+			defined[n] = true
+		}
+	}
+
+	for _, key := range []string{"command", "args"} {
+		values, _ := container[key].([]interface{})
+		for i, v := range values {
+			s, _ := v.(string)
+			for _, ref := range envVarReferences(s) {
+				if !defined[ref] {
+					errs = append(errs, fmt.Sprintf("container %q: %s.%d references $(%s)", name, key, i, ref))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// envVarReferences returns the names referenced as `$(VAR)` in s, following the
+// Kubernetes expansion rules where `$$` escapes a reference.
+func envVarReferences(s string) []string {
+	var refs []string
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != '$' {
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			i++
+		case '(':
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return refs
+			}
+			refs = append(refs, s[i+2:i+2+end])
+			i += 2 + end
+		}
+	}
+	return refs
+}
+
+var preStopSleepRegexp = regexp.MustCompile(`(?:^|[\s;&|])sleep\s+(\d+)s?(?:$|[\s;&|])`)
+
+// preStopSleepSeconds returns how long the exec pre_stop hook of a container
+// sleeps, e.g. `["sleep", "15"]` or `["sh", "-c", "sleep 15 && nginx -s quit"]`.
+// Other hooks can't be timed at plan time.
+func preStopSleepSeconds(container map[string]interface{}) (int, bool) {
+	lifecycle, _ := container["lifecycle"].([]interface{})
+	if len(lifecycle) == 0 || lifecycle[0] == nil {
+		return 0, false
+	}
+	preStop, _ := lifecycle[0].(map[string]interface{})["pre_stop"].([]interface{})
+	if len(preStop) == 0 || preStop[0] == nil {
+		return 0, false
+	}
+	exec, _ := preStop[0].(map[string]interface{})["exec"].([]interface{})
+	if len(exec) == 0 || exec[0] == nil {
+		return 0, false
+	}
+	command, _ := exec[0].(map[string]interface{})["command"].([]interface{})
+	m := preStopSleepRegexp.FindStringSubmatch(strings.Join(expandStringSlice(command), " "))
+	if m == nil {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	return seconds, true
+}
+
+// terminationGracePeriodOverrun describes the termination timing of a
+// container when the sleep of its pre_stop hook plus the time its readiness
+// probe needs to fail exceed the grace period: the kubelet then kills the
+// container before it stopped receiving traffic and drained it.
+func terminationGracePeriodOverrun(container map[string]interface{}, gracePeriod int) (string, bool) {
+	name, _ := container["name"].(string)
+	sleep, hasSleep := preStopSleepSeconds(container)
+
+	unready := 0
+	probe, _ := container["readiness_probe"].([]interface{})
+	hasProbe := len(probe) > 0 && probe[0] != nil
+	if hasProbe {
+		p := probe[0].(map[string]interface{})
+		failures, _ := p["failure_threshold"].(int)
+		period, _ := p["period_seconds"].(int)
+		unready = failures * period
+	}
+	if !hasSleep && !hasProbe {
+		return "", false
+	}
+
+	total := sleep + unready
+	if total <= gracePeriod {
+		return "", false
+	}
+	return fmt.Sprintf("container %q: pre_stop sleeps %ds and the readiness probe needs %ds to fail, %ds in total, "+
+		"more than the %ds termination grace period", name, sleep, unready, total, gracePeriod), true
+}
+
+// readOnlyRootFilesystemWritablePaths are paths most applications write to,
+// e.g. for temporary files, and so break on a read-only root filesystem.
+var readOnlyRootFilesystemWritablePaths = []string{"/tmp"}
+
+// readOnlyVolumeSources are the volume sources whose content is managed by
+// the kubelet, which containers can't write to.
+var readOnlyVolumeSources = []string{"config_map", "downward_api", "secret"}
+
+// missingWritablePaths returns the paths of readOnlyRootFilesystemWritablePaths
+// a container with a read-only root filesystem has no writable volume mounted
+// at, neither at the path itself nor at one of its parents.
+func missingWritablePaths(container map[string]interface{}, volumes []interface{}) []string {
+	sc, _ := container["security_context"].([]interface{})
+	if len(sc) == 0 || sc[0] == nil {
+		return nil
+	}
+	if readOnly, _ := sc[0].(map[string]interface{})["read_only_root_filesystem"].(bool); !readOnly {
+		return nil
+	}
+
+	writable := make(map[string]bool)
+	for _, v := range volumes {
+		volume, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := volume["name"].(string)
+		writable[name] = true
+		for _, source := range readOnlyVolumeSources {
+			if l, ok := volume[source].([]interface{}); ok && len(l) > 0 {
+				writable[name] = false
+			}
+		}
+	}
+
+	var missing []string
+	mounts, _ := container["volume_mount"].([]interface{})
+	for _, path := range readOnlyRootFilesystemWritablePaths {
+		found := false
+		for _, m := range mounts {
+			mount, ok := m.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := mount["name"].(string)
+			readOnly, _ := mount["read_only"].(bool)
+			mountPath, _ := mount["mount_path"].(string)
+			mountPath = strings.TrimSuffix(mountPath, "/")
+			if writable[name] && !readOnly && (path == mountPath || strings.HasPrefix(path, mountPath+"/")) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+func validateProxyURL(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if v == "" {
+		return
+	}
+	u, err := url.Parse(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid URL: %s", key, v, err))
+		return
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		es = append(es, fmt.Errorf("%s (%q) must use one of the http, https or socks5 schemes", key, v))
+	}
+	if u.Host == "" {
+		es = append(es, fmt.Errorf("%s (%q) must include a host", key, v))
+	}
+	return
+}
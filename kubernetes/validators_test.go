@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -25,3 +27,317 @@ func TestValidateModeBits(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateLabelSelector(t *testing.T) {
+	validCases := []string{
+		"", "team=payments", "team=payments,env!=dev", "tier in (frontend,backend)", "!legacy",
+	}
+	for _, selector := range validCases {
+		_, es := validateLabelSelector(selector, "label_selector")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", selector, es)
+		}
+	}
+
+	invalidCases := []string{
+		"team=-payments", "tier in frontend", "=payments",
+	}
+	for _, selector := range invalidCases {
+		_, es := validateLabelSelector(selector, "label_selector")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", selector)
+		}
+	}
+}
+
+func TestValidateIPAddress(t *testing.T) {
+	validCases := []string{
+		"10.0.0.1", "192.168.1.240", "2001:db8::1",
+	}
+	for _, ip := range validCases {
+		_, es := validateIPAddress(ip, "ip")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", ip, es)
+		}
+	}
+
+	invalidCases := []string{
+		"", "10.0.0", "10.0.0.256", "lb.example.com",
+	}
+	for _, ip := range invalidCases {
+		_, es := validateIPAddress(ip, "ip")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", ip)
+		}
+	}
+}
+
+func TestValidateDNSPolicy(t *testing.T) {
+	validCases := []string{
+		"ClusterFirst", "ClusterFirstWithHostNet", "Default",
+	}
+	for _, policy := range validCases {
+		_, es := validateDNSPolicy(policy, "dns_policy")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", policy, es)
+		}
+	}
+
+	invalidCases := []string{
+		"", "None", "clusterfirst", "ClusterFirstWithHostNetwork",
+	}
+	for _, policy := range invalidCases {
+		_, es := validateDNSPolicy(policy, "dns_policy")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", policy)
+		}
+	}
+}
+
+func TestValidateIntOrPercent(t *testing.T) {
+	validCases := []string{
+		"0", "1", "10", "0%", "25%", "100%",
+	}
+	for _, v := range validCases {
+		_, es := validateIntOrPercent(v, "max_surge")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", v, es)
+		}
+	}
+
+	invalidCases := []string{
+		"", "-1", "01", "1.5", "25 %", "%", "abc", "-25%",
+	}
+	for _, v := range invalidCases {
+		_, es := validateIntOrPercent(v, "max_surge")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestValidateNonNegativeIntegerString(t *testing.T) {
+	validCases := []string{
+		"", "0", "1", "300",
+	}
+	for _, v := range validCases {
+		_, es := validateNonNegativeIntegerString(v, "toleration_seconds")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", v, es)
+		}
+	}
+
+	invalidCases := []string{
+		"-1", "1.5", "5m", "abc",
+	}
+	for _, v := range invalidCases {
+		_, es := validateNonNegativeIntegerString(v, "toleration_seconds")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	validCases := []string{
+		"lb.example.com", "my-lb-1234.us-east-1.elb.amazonaws.com",
+	}
+	for _, hostname := range validCases {
+		_, es := validateHostname(hostname, "hostname")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", hostname, es)
+		}
+	}
+
+	invalidCases := []string{
+		"", "LB.example.com", "lb_1.example.com", "-lb.example.com",
+	}
+	for _, hostname := range invalidCases {
+		_, es := validateHostname(hostname, "hostname")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", hostname)
+		}
+	}
+}
+
+func TestEnvVarReferences(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected []string
+	}{
+		{"", nil},
+		{"plain value", nil},
+		{"$(FOO)", []string{"FOO"}},
+		{"--addr=$(HOST):$(PORT)", []string{"HOST", "PORT"}},
+		{"$$(ESCAPED) $(FOO)", []string{"FOO"}},
+		{"$$$(FOO)", []string{"FOO"}},
+		{"$FOO ${BAR}", nil},
+		{"$(UNTERMINATED", nil},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			refs := envVarReferences(tc.Input)
+			if !reflect.DeepEqual(refs, tc.Expected) {
+				t.Fatalf("Unexpected references in %q.\nExpected: %#v\nGiven:    %#v", tc.Input, tc.Expected, refs)
+			}
+		})
+	}
+}
+
+func TestUndefinedEnvVarReferences(t *testing.T) {
+	container := map[string]interface{}{
+		"name":    "app",
+		"command": []interface{}{"/bin/app", "--listen=$(HOST):$(PORT)"},
+		"args":    []interface{}{"--log-level=$(LOG_LEVEL)", "$$(LITERAL)"},
+		"env": []interface{}{
+			map[string]interface{}{"name": "URL", "value": "http://$(HOST):$(PORT)"},
+			map[string]interface{}{"name": "HOST", "value": "0.0.0.0"},
+			map[string]interface{}{"name": "PORT", "value": "8080"},
+		},
+	}
+
+	expected := []string{
+		`container "app": env.0.value references $(HOST)`,
+		`container "app": env.0.value references $(PORT)`,
+		`container "app": args.0 references $(LOG_LEVEL)`,
+	}
+	errs := undefinedEnvVarReferences(container)
+	if !reflect.DeepEqual(errs, expected) {
+		t.Fatalf("Unexpected undefined references.\nExpected: %#v\nGiven:    %#v", expected, errs)
+	}
+
+	container["env_from"] = []interface{}{map[string]interface{}{}}
+	if errs := undefinedEnvVarReferences(container); len(errs) > 0 {
+		t.Fatalf("Expected containers using env_from to be skipped, given: %#v", errs)
+	}
+}
+
+func TestTerminationGracePeriodOverrun(t *testing.T) {
+	preStop := func(command ...interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"pre_stop": []interface{}{map[string]interface{}{
+				"exec": []interface{}{map[string]interface{}{"command": command}},
+			}},
+		}}
+	}
+	readinessProbe := []interface{}{map[string]interface{}{"failure_threshold": 3, "period_seconds": 10}}
+
+	cases := []struct {
+		Name      string
+		Container map[string]interface{}
+		Expected  string
+	}{
+		{
+			"no hook nor probe",
+			map[string]interface{}{"name": "web"},
+			"",
+		},
+		{
+			"within the grace period",
+			map[string]interface{}{"name": "web", "lifecycle": preStop("sleep", "10"), "readiness_probe": []interface{}{
+				map[string]interface{}{"failure_threshold": 2, "period_seconds": 10},
+			}},
+			"",
+		},
+		{
+			"sleep in a shell",
+			map[string]interface{}{"name": "web", "lifecycle": preStop("sh", "-c", "sleep 25 && nginx -s quit"), "readiness_probe": readinessProbe},
+			`container "web": pre_stop sleeps 25s and the readiness probe needs 30s to fail, 55s in total, more than the 30s termination grace period`,
+		},
+		{
+			"untimed hook",
+			map[string]interface{}{"name": "web", "lifecycle": preStop("nginx", "-s", "quit"), "readiness_probe": []interface{}{
+				map[string]interface{}{"failure_threshold": 4, "period_seconds": 10},
+			}},
+			`container "web": pre_stop sleeps 0s and the readiness probe needs 40s to fail, 40s in total, more than the 30s termination grace period`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			msg, overrun := terminationGracePeriodOverrun(tc.Container, 30)
+			if overrun != (tc.Expected != "") || msg != tc.Expected {
+				t.Fatalf("Unexpected overrun.\nExpected: %q\nGiven:    %q", tc.Expected, msg)
+			}
+		})
+	}
+}
+
+func TestValidateProxyURL(t *testing.T) {
+	validCases := []string{
+		"", "http://proxy.example.com:3128", "https://10.0.0.1", "socks5://127.0.0.1:1080",
+	}
+	for _, proxy := range validCases {
+		_, es := validateProxyURL(proxy, "proxy_url")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", proxy, es)
+		}
+	}
+
+	invalidCases := []string{
+		"proxy.example.com:3128", "ftp://proxy.example.com", "http://", "://proxy",
+	}
+	for _, proxy := range invalidCases {
+		_, es := validateProxyURL(proxy, "proxy_url")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", proxy)
+		}
+	}
+}
+
+func TestMissingWritablePaths(t *testing.T) {
+	readOnlyRoot := []interface{}{map[string]interface{}{"read_only_root_filesystem": true}}
+	mount := func(name, path string, readOnly bool) []interface{} {
+		return []interface{}{map[string]interface{}{"name": name, "mount_path": path, "read_only": readOnly}}
+	}
+	volumes := []interface{}{
+		map[string]interface{}{"name": "scratch", "empty_dir": []interface{}{map[string]interface{}{}}},
+		map[string]interface{}{"name": "config", "config_map": []interface{}{map[string]interface{}{"name": "app"}}},
+	}
+
+	cases := []struct {
+		Name      string
+		Container map[string]interface{}
+		Expected  []string
+	}{
+		{
+			"writable root filesystem",
+			map[string]interface{}{"name": "web"},
+			nil,
+		},
+		{
+			"nothing mounted",
+			map[string]interface{}{"name": "web", "security_context": readOnlyRoot},
+			[]string{"/tmp"},
+		},
+		{
+			"empty dir at the path",
+			map[string]interface{}{"name": "web", "security_context": readOnlyRoot, "volume_mount": mount("scratch", "/tmp/", false)},
+			nil,
+		},
+		{
+			"empty dir at another path",
+			map[string]interface{}{"name": "web", "security_context": readOnlyRoot, "volume_mount": mount("scratch", "/tmpfiles", false)},
+			[]string{"/tmp"},
+		},
+		{
+			"read-only mount",
+			map[string]interface{}{"name": "web", "security_context": readOnlyRoot, "volume_mount": mount("scratch", "/tmp", true)},
+			[]string{"/tmp"},
+		},
+		{
+			"config map",
+			map[string]interface{}{"name": "web", "security_context": readOnlyRoot, "volume_mount": mount("config", "/tmp", false)},
+			[]string{"/tmp"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			missing := missingWritablePaths(tc.Container, volumes)
+			if !reflect.DeepEqual(missing, tc.Expected) {
+				t.Fatalf("Unexpected missing paths.\nExpected: %#v\nGiven:    %#v", tc.Expected, missing)
+			}
+		})
+	}
+}